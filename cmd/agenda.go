@@ -0,0 +1,138 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/intiramisu/note-cli/internal/config"
+	"github.com/intiramisu/note-cli/internal/note"
+	"github.com/intiramisu/note-cli/internal/task"
+	"github.com/spf13/cobra"
+)
+
+var agendaCmd = &cobra.Command{
+	Use:   "agenda",
+	Short: "Show pending tasks grouped by due date",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		days, _ := cmd.Flags().GetInt("days")
+		cfg := config.Global
+		if days <= 0 {
+			days = cfg.Display.AgendaDays
+		}
+
+		storage, err := newStorage()
+		if err != nil {
+			return err
+		}
+
+		manager, err := newTaskManager()
+		if err != nil {
+			return err
+		}
+
+		printDailySummary(storage, cfg)
+
+		groups := manager.Agenda(days)
+		total := 0
+		for _, g := range groups {
+			total += len(g.Tasks)
+		}
+		if total == 0 {
+			fmt.Println("タスクがありません")
+			return nil
+		}
+
+		noteTitles := make(map[string]string)
+		for _, g := range groups {
+			if len(g.Tasks) == 0 {
+				continue
+			}
+			fmt.Printf("\n%s %s (%d)\n", agendaIcon(g.Bucket), g.Bucket, len(g.Tasks))
+			for _, t := range g.Tasks {
+				priorityStr := ""
+				if t.Priority != task.PriorityNone {
+					priorityStr = fmt.Sprintf(" (%s)", t.Priority.String())
+				}
+				noteStr := ""
+				if t.HasNote() {
+					noteStr = fmt.Sprintf(" %s %s", cfg.Theme.Symbols.NoteIcon, resolveNoteTitle(storage, noteTitles, t.NoteID))
+				}
+				dueStr := ""
+				if t.HasDueDate() {
+					dueStr = fmt.Sprintf(" 📅 %s", t.DueDate.Format("01/02"))
+				}
+				fmt.Printf("  [ ] [%d]%s %s%s%s\n", t.ID, priorityStr, t.Description, noteStr, dueStr)
+			}
+		}
+
+		return nil
+	},
+}
+
+func agendaIcon(b task.AgendaBucket) string {
+	switch b {
+	case task.BucketOverdue:
+		return "⚠️"
+	case task.BucketToday:
+		return "🔥"
+	case task.BucketTomorrow:
+		return "⏰"
+	case task.BucketThisWeek:
+		return "📅"
+	case task.BucketLater:
+		return "🗓"
+	default:
+		return "📝"
+	}
+}
+
+// resolveNoteTitle はタスクに紐づくメモIDをタイトルに解決する
+// (見つからない・複数のメモに該当する場合はIDのまま)
+func resolveNoteTitle(storage *note.Storage, cache map[string]string, noteID string) string {
+	if title, ok := cache[noteID]; ok {
+		return title
+	}
+	title := noteID
+	if n, err := note.PreferExact(storage.Find(noteID)); err == nil {
+		title = n.Title
+	}
+	cache[noteID] = title
+	return title
+}
+
+// printDailySummary は今日のデイリーノートの未完了チェック項目を表示する
+func printDailySummary(storage *note.Storage, cfg *config.Config) {
+	dateStr := time.Now().Format(cfg.Formats.Date)
+	n, err := storage.Load(filepath.Join(cfg.Paths.DailyDir, dateStr+".md"))
+	if err != nil {
+		fmt.Printf("%s 今日のデイリーノート: (未作成)\n", cfg.Theme.Symbols.DailyIcon)
+		return
+	}
+
+	var open []string
+	doneCount := 0
+	for _, line := range strings.Split(n.Content, "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(line, "- [ ]"):
+			if item := strings.TrimSpace(strings.TrimPrefix(line, "- [ ]")); item != "" {
+				open = append(open, item)
+			}
+		case strings.HasPrefix(line, "- [x]"), strings.HasPrefix(line, "- [X]"):
+			doneCount++
+		}
+	}
+
+	fmt.Printf("%s 今日のデイリーノート: %s (未完了 %d / 完了 %d)\n", cfg.Theme.Symbols.DailyIcon, n.Title, len(open), doneCount)
+	for _, item := range open {
+		fmt.Printf("  - [ ] %s\n", item)
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(agendaCmd)
+
+	agendaCmd.Flags().Int("days", 0, "number of days treated as upcoming (default: display.agenda_days)")
+}
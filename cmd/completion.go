@@ -0,0 +1,208 @@
+package cmd
+
+import (
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/intiramisu/note-cli/internal/config"
+	"github.com/intiramisu/note-cli/internal/note"
+	"github.com/intiramisu/note-cli/internal/task"
+	"github.com/spf13/cobra"
+)
+
+// 補完関数は __complete 実行時に呼ばれる。--config はその時点でパースされるため
+// 設定を読み込み直してから使う。
+func completionStorage() (*note.Storage, error) {
+	initConfig()
+	return newStorage()
+}
+
+func completionTaskManager() (*task.Manager, error) {
+	initConfig()
+	return newTaskManager()
+}
+
+// filterPrefix keeps candidates (optionally "value\tdescription") whose value starts with toComplete.
+func filterPrefix(candidates []string, toComplete string) []string {
+	var out []string
+	lower := strings.ToLower(toComplete)
+	for _, c := range candidates {
+		value, _, _ := strings.Cut(c, "\t")
+		if strings.HasPrefix(strings.ToLower(value), lower) {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// noteQuery returns the string that Storage.Find resolves back to n:
+// the title for top-level notes, the path without ".md" for notes in subdirectories.
+func noteQuery(n *note.Note) string {
+	if filepath.Dir(n.ID) == "." {
+		return n.Title
+	}
+	return strings.TrimSuffix(n.ID, ".md")
+}
+
+// completeNoteTitles completes the first argument with note titles.
+func completeNoteTitles(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return completeNotes(cmd, args, toComplete)
+}
+
+// completeNotes completes note titles/paths, e.g. for --note.
+func completeNotes(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	storage, err := completionStorage()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+	notes, err := storage.List("")
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+
+	var candidates []string
+	for _, n := range notes {
+		desc := n.Modified.Format(config.Global.Formats.Date)
+		if len(n.Tags) > 0 {
+			desc += " [" + strings.Join(n.Tags, ", ") + "]"
+		}
+		candidates = append(candidates, noteQuery(n)+"\t"+desc)
+	}
+	return filterPrefix(candidates, toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeNoteTags completes tags used in notes.
+func completeNoteTags(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	storage, err := completionStorage()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+	notes, err := storage.List("")
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+
+	counts := make(map[string]int)
+	for _, n := range notes {
+		for _, tag := range n.Tags {
+			counts[tag]++
+		}
+	}
+	return filterPrefix(countedCandidates(counts), toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeTaskTags completes tags used in tasks.
+func completeTaskTags(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	manager, err := completionTaskManager()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+
+	counts := make(map[string]int)
+	for _, t := range manager.List(true) {
+		for _, tag := range t.Tags {
+			counts[tag]++
+		}
+	}
+	return filterPrefix(countedCandidates(counts), toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeTaskProjects completes project names used in tasks.
+func completeTaskProjects(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	manager, err := completionTaskManager()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+
+	counts := make(map[string]int)
+	for _, t := range manager.List(true) {
+		if t.Project != "" {
+			counts[t.Project]++
+		}
+	}
+	return filterPrefix(countedCandidates(counts), toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeTaskViews completes the view names in task_views.
+func completeTaskViews(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	var candidates []string
+	for name, query := range config.Global.TaskViews {
+		candidates = append(candidates, name+"\t"+query)
+	}
+	sort.Strings(candidates)
+	return filterPrefix(candidates, toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+func countedCandidates(counts map[string]int) []string {
+	var candidates []string
+	for name, n := range counts {
+		candidates = append(candidates, name+"\t"+strconv.Itoa(n)+" 件")
+	}
+	sort.Strings(candidates)
+	return candidates
+}
+
+// completeTemplates completes template names in Paths.TemplatesDir.
+func completeTemplates(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	initConfig()
+	names, err := note.ListTemplates(config.Global.GetTemplatesPath())
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return filterPrefix(names, toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+// taskIDCompletion completes task IDs with their descriptions.
+func taskIDCompletion(includeDone bool) cobra.CompletionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		manager, err := completionTaskManager()
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveError
+		}
+
+		// 複数指定できるので、指定済みのIDは候補から外す
+		var candidates []string
+		for _, t := range manager.List(includeDone) {
+			if id := strconv.Itoa(t.ID); !slices.Contains(args, id) {
+				candidates = append(candidates, id+"\t"+t.Description)
+			}
+		}
+		return filterPrefix(candidates, toComplete), cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveKeepOrder
+	}
+}
+
+// completeDueDate completes keywords accepted by util.ParseDueDate.
+func completeDueDate(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	return filterPrefix([]string{
+		"today\t今日",
+		"tomorrow\t明日",
+		"+1\t1日後",
+		"+3\t3日後",
+		"+7\t1週間後",
+	}, toComplete), cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveKeepOrder
+}
+
+// completeDailyDate completes keywords accepted by util.ParseDate.
+func completeDailyDate(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return filterPrefix([]string{
+		"today\t今日",
+		"yesterday\t昨日",
+		"tomorrow\t明日",
+		"-1\t1日前",
+		"+1\t1日後",
+	}, toComplete), cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveKeepOrder
+}
+
+// completeNothing disables file completion for free-text arguments.
+func completeNothing(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	return nil, cobra.ShellCompDirectiveNoFileComp
+}
@@ -1,9 +1,11 @@
 package cmd
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 
 	"github.com/spf13/cobra"
@@ -22,6 +24,10 @@ var configShowCmd = &cobra.Command{
 	RunE: func(cmd *cobra.Command, args []string) error {
 		settings := viper.AllSettings()
 
+		if structuredOutput() {
+			return printConfig(settings)
+		}
+
 		data, err := yaml.Marshal(settings)
 		if err != nil {
 			return fmt.Errorf("設定の表示に失敗: %w", err)
@@ -39,6 +45,29 @@ var configShowCmd = &cobra.Command{
 	},
 }
 
+// configRecord is the machine-readable form of `config show`.
+type configRecord struct {
+	ConfigFile string         `json:"config_file" yaml:"config_file"`
+	Settings   map[string]any `json:"settings" yaml:"settings"`
+}
+
+func printConfig(settings map[string]any) error {
+	table := tsvTable{header: []string{"key", "value"}}
+	keys := viper.AllKeys()
+	sort.Strings(keys)
+	for _, key := range keys {
+		value := viper.Get(key)
+		cell := fmt.Sprint(value)
+		switch value.(type) {
+		case []any, map[string]any:
+			data, _ := json.Marshal(value)
+			cell = string(data)
+		}
+		table.rows = append(table.rows, []string{key, cell})
+	}
+	return printStructured(configRecord{ConfigFile: viper.ConfigFileUsed(), Settings: settings}, table)
+}
+
 var configSetCmd = &cobra.Command{
 	Use:   "set <key> <value>",
 	Short: "Set a configuration value",
@@ -0,0 +1,34 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"text/template"
+
+	"github.com/intiramisu/note-cli/internal/config"
+	"github.com/intiramisu/note-cli/internal/util"
+)
+
+// listTemplate resolves a --format value (preset name or template text) into a template.
+func listTemplate(format string, presets map[string]string) (*template.Template, error) {
+	if structuredOutput() {
+		return nil, fmt.Errorf("--format と --output は同時に指定できません")
+	}
+	if preset, ok := presets[format]; ok {
+		format = preset
+	}
+	cfg := config.Global
+	tmpl, err := util.ParseListTemplate(format, util.TemplateFuncs(cfg.Formats.Date, cfg.Formats.DateTime))
+	if err != nil {
+		return nil, fmt.Errorf("フォーマットの解析に失敗: %w", err)
+	}
+	return tmpl, nil
+}
+
+// printWithTemplate renders one list item with tmpl.
+func printWithTemplate(tmpl *template.Template, item any) error {
+	if err := tmpl.Execute(os.Stdout, item); err != nil {
+		return fmt.Errorf("フォーマットの適用に失敗: %w", err)
+	}
+	return nil
+}
@@ -1,11 +1,23 @@
 package cmd
 
 import (
+	"fmt"
+	"os"
+
 	"github.com/intiramisu/note-cli/internal/config"
 	"github.com/intiramisu/note-cli/internal/note"
 	"github.com/intiramisu/note-cli/internal/task"
+	"github.com/intiramisu/note-cli/internal/ui"
+	"github.com/intiramisu/note-cli/internal/util"
 )
 
+// exitAmbiguous is the exit status when a note query matches several notes
+// and no choice could be made (non-interactive run without --first).
+const exitAmbiguous = 2
+
+// firstMatch makes ambiguous note queries resolve to the newest candidate.
+var firstMatch bool
+
 func newStorage() (*note.Storage, error) {
 	return note.NewStorage(config.Global.NotesDir)
 }
@@ -13,3 +25,41 @@ func newStorage() (*note.Storage, error) {
 func newTaskManager() (*task.Manager, error) {
 	return task.NewManager(config.Global.NotesDir)
 }
+
+// autoArchive archives old completed tasks when archive.auto is set. It is
+// called by the commands that change tasks and by the TUIs; a failure only
+// prints a warning so the command itself still runs.
+func autoArchive(manager *task.Manager) {
+	if !config.Global.Archive.Auto {
+		return
+	}
+	olderThan, err := util.ParsePeriod(config.Global.Archive.OlderThan)
+	if err == nil {
+		_, err = manager.Archive(olderThan)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "自動アーカイブに失敗: %v\n", err)
+	}
+}
+
+// findNote resolves a note query. When several notes match, it asks the user
+// to pick one on a terminal, takes the newest with --first, and otherwise
+// returns a *note.AmbiguousError.
+func findNote(storage *note.Storage, query string) (*note.Note, error) {
+	candidates, err := storage.FindAll(query)
+	if err != nil {
+		return nil, err
+	}
+	if len(candidates) == 1 || firstMatch {
+		return candidates[0], nil
+	}
+	if structuredOutput() || !isTerminal(os.Stdin) || !isTerminal(os.Stderr) {
+		return nil, &note.AmbiguousError{Query: query, Candidates: candidates}
+	}
+	return ui.PickNote(query, candidates)
+}
+
+func isTerminal(f *os.File) bool {
+	fi, err := f.Stat()
+	return err == nil && fi.Mode()&os.ModeCharDevice != 0
+}
@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/intiramisu/note-cli/internal/config"
+	"github.com/intiramisu/note-cli/internal/note"
+	"github.com/spf13/cobra"
+)
+
+// デイリーノートのインボックスは日付が自明なので時刻だけ付ける
+const dailyInboxLayout = "15:04"
+
+var inboxCmd = &cobra.Command{
+	Use:     "inbox [text...]",
+	Aliases: []string{"i"},
+	Short:   "Quickly capture a thought into the inbox",
+	Long: `Append a timestamped bullet to the inbox.
+The inbox is today's daily note unless inbox.note is set in the config.
+Reads the text from stdin when it is not given as arguments.`,
+	ValidArgsFunction: completeNothing,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg := config.Global
+
+		text := strings.Join(args, " ")
+		if len(args) == 0 {
+			input, err := readStdin()
+			if err != nil {
+				return err
+			}
+			text = input
+		}
+		text = strings.Join(strings.Fields(text), " ")
+		if text == "" {
+			return fmt.Errorf("追記する内容がありません")
+		}
+
+		storage, err := newStorage()
+		if err != nil {
+			return err
+		}
+
+		noteID, layout, err := ensureInboxNote(storage)
+		if err != nil {
+			return err
+		}
+
+		entry := fmt.Sprintf("- %s %s", time.Now().Format(layout), text)
+		if err := storage.Append(noteID, entry, cfg.Inbox.Heading); err != nil {
+			return err
+		}
+
+		fmt.Printf("📥 %s\n", text)
+		return nil
+	},
+}
+
+// ensureInboxNote returns the inbox note ID (creating the note if needed)
+// and the timestamp layout for its entries.
+func ensureInboxNote(storage *note.Storage) (string, string, error) {
+	cfg := config.Global
+	now := time.Now()
+
+	if cfg.Inbox.Note == "" {
+		if _, _, err := storage.EnsureDaily(cfg, now); err != nil {
+			return "", "", err
+		}
+		return cfg.GetDailyNoteID(now), dailyInboxLayout, nil
+	}
+
+	// 部分一致で複数のメモに該当した場合は、どれに追記するか決められないのでエラーにする
+	n, err := note.PreferExact(storage.Find(cfg.Inbox.Note))
+	if errors.Is(err, note.ErrNotFound) {
+		n = note.NewNote(cfg.Inbox.Note, []string{"inbox"})
+		if err := storage.Save(n); err != nil {
+			return "", "", err
+		}
+	} else if err != nil {
+		return "", "", err
+	}
+	return n.ID, cfg.Formats.DateTime, nil
+}
+
+func init() {
+	rootCmd.AddCommand(inboxCmd)
+}
@@ -2,10 +2,12 @@ package cmd
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/intiramisu/note-cli/internal/config"
 	"github.com/intiramisu/note-cli/internal/note"
@@ -30,13 +32,16 @@ var noteCmd = &cobra.Command{
 }
 
 var noteCreateCmd = &cobra.Command{
-	Use:   "create <title>",
-	Short: "Create a new note",
-	Args:  cobra.MinimumNArgs(1),
+	Use:               "create <title>",
+	Short:             "Create a new note",
+	Args:              cobra.MinimumNArgs(1),
+	ValidArgsFunction: completeNothing,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		title := strings.Join(args, " ")
 		tags, _ := cmd.Flags().GetStringSlice("tag")
 		templateName, _ := cmd.Flags().GetString("template")
+		fromStdin, _ := cmd.Flags().GetBool("stdin")
+		noEdit, _ := cmd.Flags().GetBool("no-edit")
 
 		notesDir := config.Global.NotesDir
 		storage, err := note.NewStorage(notesDir)
@@ -55,27 +60,40 @@ var noteCreateCmd = &cobra.Command{
 			n.Content = content
 		}
 
+		// 標準入力の内容をテンプレートの後ろに追加
+		if fromStdin {
+			input, err := readStdin()
+			if err != nil {
+				return err
+			}
+			if n.Content != "" && !strings.HasSuffix(n.Content, "\n") {
+				n.Content += "\n"
+			}
+			n.Content += input
+		}
+
 		if err := storage.Save(n); err != nil {
 			return err
 		}
 
 		fmt.Printf("メモを作成しました: %s\n", n.ID)
+		if noEdit || fromStdin {
+			return nil
+		}
 		return openEditor(storage.GetPath(n.ID))
 	},
 }
 
 func loadTemplate(notesDir, name, title string) (string, error) {
-	cfg := config.Global
-	templatesDir := cfg.Paths.TemplatesDir
-	templatePath := filepath.Join(notesDir, templatesDir, name+".md")
-	data, err := os.ReadFile(templatePath)
+	return note.LoadTemplate(filepath.Join(notesDir, config.Global.Paths.TemplatesDir), name, title)
+}
+
+func readStdin() (string, error) {
+	data, err := io.ReadAll(os.Stdin)
 	if err != nil {
-		return "", fmt.Errorf("テンプレートが見つかりません: %s", name)
+		return "", fmt.Errorf("標準入力の読み込みに失敗: %w", err)
 	}
-
-	content := string(data)
-	content = strings.ReplaceAll(content, "{{title}}", title)
-	return content, nil
+	return string(data), nil
 }
 
 var noteListCmd = &cobra.Command{
@@ -83,6 +101,7 @@ var noteListCmd = &cobra.Command{
 	Short: "List all notes",
 	RunE: func(cmd *cobra.Command, args []string) error {
 		tagFilter, _ := cmd.Flags().GetString("tag")
+		format, _ := cmd.Flags().GetString("format")
 		cfg := config.Global
 
 		storage, err := newStorage()
@@ -95,6 +114,23 @@ var noteListCmd = &cobra.Command{
 			return err
 		}
 
+		if format != "" {
+			tmpl, err := listTemplate(format, cfg.ListFormats.Notes)
+			if err != nil {
+				return err
+			}
+			for _, n := range notes {
+				if err := printWithTemplate(tmpl, n); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+
+		if structuredOutput() {
+			return printNotes(storage, notes)
+		}
+
 		if len(notes) == 0 {
 			fmt.Println("メモがありません")
 			return nil
@@ -119,9 +155,10 @@ var noteListCmd = &cobra.Command{
 }
 
 var noteShowCmd = &cobra.Command{
-	Use:   "show <title>",
-	Short: "Show note content",
-	Args:  cobra.MinimumNArgs(1),
+	Use:               "show <title>",
+	Short:             "Show note content",
+	Args:              cobra.MinimumNArgs(1),
+	ValidArgsFunction: completeNoteTitles,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		query := strings.Join(args, " ")
 		cfg := config.Global
@@ -131,11 +168,15 @@ var noteShowCmd = &cobra.Command{
 			return err
 		}
 
-		n, err := storage.Find(query)
+		n, err := findNote(storage, query)
 		if err != nil {
 			return err
 		}
 
+		if structuredOutput() {
+			return printNoteDetail(storage, n)
+		}
+
 		fmt.Printf("# %s\n", n.Title)
 		fmt.Printf("作成: %s | 更新: %s\n", n.Created.Format(cfg.Formats.DateTime), n.Modified.Format(cfg.Formats.DateTime))
 		if len(n.Tags) > 0 {
@@ -154,10 +195,13 @@ var noteShowCmd = &cobra.Command{
 		if len(links) > 0 {
 			fmt.Println()
 			fmt.Println("🔗 リンク先:")
-			found, notFound := note.ResolveLinks(storage, links)
+			found, notFound, ambiguous := note.ResolveLinks(storage, links)
 			for _, ln := range found {
 				fmt.Printf("  ✓ %s\n", ln.Title)
 			}
+			for _, amb := range ambiguous {
+				fmt.Printf("  ? %s (複数該当: %d 件)\n", amb.Query, len(amb.Candidates))
+			}
 			for _, name := range notFound {
 				fmt.Printf("  ✗ %s (未作成)\n", name)
 			}
@@ -177,10 +221,56 @@ var noteShowCmd = &cobra.Command{
 	},
 }
 
+var noteAppendCmd = &cobra.Command{
+	Use:   "append <title> [text...]",
+	Short: "Append text to a note",
+	Long: `Append text to the end of a note, or to the end of a section with --heading.
+Reads the text from stdin when it is not given as arguments.`,
+	Args:              cobra.MinimumNArgs(1),
+	ValidArgsFunction: completeNoteTitles,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		heading, _ := cmd.Flags().GetString("heading")
+		timestamp, _ := cmd.Flags().GetBool("timestamp")
+
+		text := strings.Join(args[1:], " ")
+		if len(args) == 1 {
+			input, err := readStdin()
+			if err != nil {
+				return err
+			}
+			text = input
+		}
+		if strings.TrimSpace(text) == "" {
+			return fmt.Errorf("追記する内容がありません")
+		}
+		if timestamp {
+			text = fmt.Sprintf("[%s] %s", time.Now().Format(config.Global.Formats.DateTime), text)
+		}
+
+		storage, err := newStorage()
+		if err != nil {
+			return err
+		}
+
+		n, err := findNote(storage, args[0])
+		if err != nil {
+			return err
+		}
+
+		if err := storage.Append(n.ID, text, heading); err != nil {
+			return err
+		}
+
+		fmt.Printf("メモ「%s」に追記しました\n", n.Title)
+		return nil
+	},
+}
+
 var noteEditCmd = &cobra.Command{
-	Use:   "edit <title>",
-	Short: "Edit a note",
-	Args:  cobra.MinimumNArgs(1),
+	Use:               "edit <title>",
+	Short:             "Edit a note",
+	Args:              cobra.MinimumNArgs(1),
+	ValidArgsFunction: completeNoteTitles,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		query := strings.Join(args, " ")
 
@@ -189,7 +279,7 @@ var noteEditCmd = &cobra.Command{
 			return err
 		}
 
-		n, err := storage.Find(query)
+		n, err := findNote(storage, query)
 		if err != nil {
 			return err
 		}
@@ -198,9 +288,10 @@ var noteEditCmd = &cobra.Command{
 }
 
 var noteDeleteCmd = &cobra.Command{
-	Use:   "delete <title>",
-	Short: "Delete a note",
-	Args:  cobra.MinimumNArgs(1),
+	Use:               "delete <title>",
+	Short:             "Delete a note",
+	Args:              cobra.MinimumNArgs(1),
+	ValidArgsFunction: completeNoteTitles,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		query := strings.Join(args, " ")
 		force, _ := cmd.Flags().GetBool("force")
@@ -210,7 +301,7 @@ var noteDeleteCmd = &cobra.Command{
 			return err
 		}
 
-		n, err := storage.Find(query)
+		n, err := findNote(storage, query)
 		if err != nil {
 			return err
 		}
@@ -240,10 +331,20 @@ func init() {
 	noteCmd.AddCommand(noteListCmd)
 	noteCmd.AddCommand(noteShowCmd)
 	noteCmd.AddCommand(noteEditCmd)
+	noteCmd.AddCommand(noteAppendCmd)
 	noteCmd.AddCommand(noteDeleteCmd)
 
 	noteCreateCmd.Flags().StringSliceP("tag", "t", []string{}, "tags (can be specified multiple times)")
 	noteCreateCmd.Flags().StringP("template", "T", "", "template name")
+	noteCreateCmd.Flags().Bool("stdin", false, "read note content from stdin (implies --no-edit)")
+	noteCreateCmd.Flags().Bool("no-edit", false, "create the note without opening the editor")
+	noteAppendCmd.Flags().StringP("heading", "H", "", "append to the end of this section (created if missing)")
+	noteAppendCmd.Flags().Bool("timestamp", false, "prefix the text with the current date and time")
 	noteListCmd.Flags().StringP("tag", "t", "", "filter by tag")
+	noteListCmd.Flags().String("format", "", "Go template or preset name from list_formats.notes")
 	noteDeleteCmd.Flags().BoolP("force", "f", false, "delete without confirmation")
+
+	noteCreateCmd.RegisterFlagCompletionFunc("tag", completeNoteTags)
+	noteCreateCmd.RegisterFlagCompletionFunc("template", completeTemplates)
+	noteListCmd.RegisterFlagCompletionFunc("tag", completeNoteTags)
 }
@@ -0,0 +1,286 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/intiramisu/note-cli/internal/note"
+	"github.com/intiramisu/note-cli/internal/task"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// 機械可読出力のフォーマット (--output)
+const (
+	outputText = "text"
+	outputJSON = "json"
+	outputYAML = "yaml"
+	outputTSV  = "tsv"
+)
+
+var outputFormat string
+
+// outputAnnotation marks the commands whose output --output switches.
+const outputAnnotation = "structured-output"
+
+func init() {
+	for _, cmd := range []*cobra.Command{noteListCmd, listCmd, noteShowCmd, showCmd, tagsCmd, taskListCmd, configShowCmd} {
+		if cmd.Annotations == nil {
+			cmd.Annotations = map[string]string{}
+		}
+		cmd.Annotations[outputAnnotation] = "true"
+	}
+}
+
+// validateOutputFormat checks the --output value and rejects a structured
+// format for commands that only print text.
+func validateOutputFormat(cmd *cobra.Command) error {
+	switch outputFormat {
+	case outputText:
+		return nil
+	case outputJSON, outputYAML, outputTSV:
+		if cmd.Annotations[outputAnnotation] == "" {
+			return fmt.Errorf("%s は --output %s に対応していません", cmd.CommandPath(), outputFormat)
+		}
+		return nil
+	default:
+		return fmt.Errorf("未対応の出力形式: %s (text, json, yaml, tsv が使えます)", outputFormat)
+	}
+}
+
+// structuredOutput reports whether --output asks for machine-readable data.
+func structuredOutput() bool {
+	return outputFormat != outputText
+}
+
+// tsvTable is the tabular form of a structured value.
+type tsvTable struct {
+	header []string
+	rows   [][]string
+}
+
+// writeStructured prints v as JSON or YAML, or table as TSV.
+func writeStructured(w io.Writer, v any, table tsvTable) error {
+	switch outputFormat {
+	case outputJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		enc.SetEscapeHTML(false)
+		return enc.Encode(v)
+	case outputYAML:
+		enc := yaml.NewEncoder(w)
+		enc.SetIndent(2)
+		if err := enc.Encode(v); err != nil {
+			return err
+		}
+		return enc.Close()
+	case outputTSV:
+		fmt.Fprintln(w, strings.Join(table.header, "\t"))
+		for _, row := range table.rows {
+			cells := make([]string, len(row))
+			for i, c := range row {
+				cells[i] = tsvEscaper.Replace(c)
+			}
+			fmt.Fprintln(w, strings.Join(cells, "\t"))
+		}
+		return nil
+	}
+	return fmt.Errorf("未対応の出力形式: %s", outputFormat)
+}
+
+func printStructured(v any, table tsvTable) error {
+	if err := writeStructured(os.Stdout, v, table); err != nil {
+		return fmt.Errorf("出力に失敗: %w", err)
+	}
+	return nil
+}
+
+var tsvEscaper = strings.NewReplacer(`\`, `\\`, "\t", `\t`, "\n", `\n`, "\r", `\r`)
+
+func tsvTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
+
+func optionalTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
+
+// noteRecord is the stable machine-readable form of a note.
+type noteRecord struct {
+	ID       string    `json:"id" yaml:"id"`
+	Title    string    `json:"title" yaml:"title"`
+	Path     string    `json:"path" yaml:"path"`
+	Tags     []string  `json:"tags" yaml:"tags"`
+	Created  time.Time `json:"created" yaml:"created"`
+	Modified time.Time `json:"modified" yaml:"modified"`
+}
+
+type noteLinkRecord struct {
+	Name       string   `json:"name" yaml:"name"`
+	ID         string   `json:"id" yaml:"id"`
+	Title      string   `json:"title" yaml:"title"`
+	Exists     bool     `json:"exists" yaml:"exists"`
+	Candidates []string `json:"candidates,omitempty" yaml:"candidates,omitempty"` // 複数のメモに該当した場合のID
+}
+
+type noteDetailRecord struct {
+	noteRecord `yaml:",inline"`
+	Content    string           `json:"content" yaml:"content"`
+	Links      []noteLinkRecord `json:"links" yaml:"links"`
+	Backlinks  []noteRecord     `json:"backlinks" yaml:"backlinks"`
+}
+
+func newNoteRecord(storage *note.Storage, n *note.Note) noteRecord {
+	tags := n.Tags
+	if tags == nil {
+		tags = []string{}
+	}
+	return noteRecord{
+		ID:       n.ID,
+		Title:    n.Title,
+		Path:     storage.GetPath(n.ID),
+		Tags:     tags,
+		Created:  n.Created,
+		Modified: n.Modified,
+	}
+}
+
+var noteTSVHeader = []string{"id", "title", "path", "tags", "created", "modified"}
+
+func (r noteRecord) tsvRow() []string {
+	return []string{r.ID, r.Title, r.Path, strings.Join(r.Tags, ","),
+		r.Created.Format(time.RFC3339), r.Modified.Format(time.RFC3339)}
+}
+
+func printNotes(storage *note.Storage, notes []*note.Note) error {
+	records := make([]noteRecord, 0, len(notes))
+	table := tsvTable{header: noteTSVHeader}
+	for _, n := range notes {
+		r := newNoteRecord(storage, n)
+		records = append(records, r)
+		table.rows = append(table.rows, r.tsvRow())
+	}
+	return printStructured(records, table)
+}
+
+func printNoteDetail(storage *note.Storage, n *note.Note) error {
+	detail := noteDetailRecord{
+		noteRecord: newNoteRecord(storage, n),
+		Content:    n.Content,
+		Links:      []noteLinkRecord{},
+		Backlinks:  []noteRecord{},
+	}
+
+	for _, name := range note.ExtractLinks(n.Content) {
+		link := noteLinkRecord{Name: name}
+		target, err := note.PreferExact(storage.Find(name))
+		var ambiguous *note.AmbiguousError
+		switch {
+		case err == nil:
+			link.ID, link.Title, link.Exists = target.ID, target.Title, true
+		case errors.As(err, &ambiguous):
+			for _, c := range ambiguous.Candidates {
+				link.Candidates = append(link.Candidates, c.ID)
+			}
+		}
+		detail.Links = append(detail.Links, link)
+	}
+
+	backlinks, err := note.FindBacklinks(storage, n.Title)
+	if err != nil {
+		return err
+	}
+	for _, bl := range backlinks {
+		detail.Backlinks = append(detail.Backlinks, newNoteRecord(storage, bl))
+	}
+
+	var linkNames, backlinkIDs []string
+	for _, l := range detail.Links {
+		linkNames = append(linkNames, l.Name)
+	}
+	for _, bl := range detail.Backlinks {
+		backlinkIDs = append(backlinkIDs, bl.ID)
+	}
+	table := tsvTable{
+		header: append(append([]string{}, noteTSVHeader...), "links", "backlinks", "content"),
+		rows: [][]string{append(detail.tsvRow(),
+			strings.Join(linkNames, ","), strings.Join(backlinkIDs, ","), detail.Content)},
+	}
+	return printStructured(detail, table)
+}
+
+// taskRecord is the stable machine-readable form of a task.
+type taskRecord struct {
+	ID          int        `json:"id" yaml:"id"`
+	UUID        string     `json:"uuid" yaml:"uuid"`
+	Description string     `json:"description" yaml:"description"`
+	Status      string     `json:"status" yaml:"status"`
+	Priority    string     `json:"priority" yaml:"priority"`
+	Project     string     `json:"project" yaml:"project"`
+	Tags        []string   `json:"tags" yaml:"tags"`
+	Note        string     `json:"note" yaml:"note"`
+	Due         *time.Time `json:"due" yaml:"due"`
+	Overdue     bool       `json:"overdue" yaml:"overdue"`
+	Created     time.Time  `json:"created" yaml:"created"`
+	Completed   *time.Time `json:"completed" yaml:"completed"`
+}
+
+func newTaskRecord(t *task.Task) taskRecord {
+	status := "pending"
+	if t.IsDone() {
+		status = "done"
+	}
+	tags := t.Tags
+	if tags == nil {
+		tags = []string{}
+	}
+	return taskRecord{
+		ID:          t.ID,
+		UUID:        t.EnsureUUID(),
+		Description: t.Description,
+		Status:      status,
+		Priority:    t.Priority.String(),
+		Project:     t.Project,
+		Tags:        tags,
+		Note:        t.NoteID,
+		Due:         optionalTime(t.DueDate),
+		Overdue:     t.IsOverdue(),
+		Created:     t.Created,
+		Completed:   optionalTime(t.Completed),
+	}
+}
+
+func printTasks(tasks []*task.Task) error {
+	records := make([]taskRecord, 0, len(tasks))
+	table := tsvTable{header: []string{"id", "uuid", "description", "status", "priority",
+		"project", "tags", "note", "due", "overdue", "created", "completed"}}
+	for _, t := range tasks {
+		r := newTaskRecord(t)
+		records = append(records, r)
+		table.rows = append(table.rows, []string{
+			fmt.Sprint(r.ID), r.UUID, r.Description, r.Status, r.Priority, r.Project,
+			strings.Join(r.Tags, ","), r.Note, tsvTime(r.Due), fmt.Sprint(r.Overdue),
+			r.Created.Format(time.RFC3339), tsvTime(r.Completed),
+		})
+	}
+	return printStructured(records, table)
+}
+
+func printTags(tags []note.TagCount) error {
+	table := tsvTable{header: []string{"name", "count"}}
+	for _, t := range tags {
+		table.rows = append(table.rows, []string{t.Name, fmt.Sprint(t.Count)})
+	}
+	return printStructured(tags, table)
+}
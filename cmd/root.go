@@ -1,10 +1,12 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"os"
 
 	"github.com/intiramisu/note-cli/internal/config"
+	"github.com/intiramisu/note-cli/internal/note"
 	"github.com/intiramisu/note-cli/internal/ui"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -16,6 +18,9 @@ var rootCmd = &cobra.Command{
 	Use:     "note-cli",
 	Short:   "A lightweight CLI tool for notes and tasks",
 	Version: Version,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		return validateOutputFormat(cmd)
+	},
 	RunE: func(cmd *cobra.Command, args []string) error {
 		noteStorage, err := newStorage()
 		if err != nil {
@@ -26,6 +31,7 @@ var rootCmd = &cobra.Command{
 		if err != nil {
 			return err
 		}
+		autoArchive(taskManager)
 
 		return ui.Run(noteStorage, taskManager)
 	},
@@ -34,6 +40,10 @@ var rootCmd = &cobra.Command{
 func Execute() {
 	if err := rootCmd.Execute(); err != nil {
 		fmt.Fprintln(os.Stderr, err)
+		var ambiguous *note.AmbiguousError
+		if errors.As(err, &ambiguous) {
+			os.Exit(exitAmbiguous)
+		}
 		os.Exit(1)
 	}
 }
@@ -45,6 +55,8 @@ func init() {
 	initVersionInfo()
 
 	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file path")
+	rootCmd.PersistentFlags().BoolVar(&firstMatch, "first", false, "use the newest note when a note query matches several notes")
+	rootCmd.PersistentFlags().StringVar(&outputFormat, "output", outputText, "output format for list/show commands (text, json, yaml, tsv)")
 	rootCmd.Version = Version
 	rootCmd.SetVersionTemplate("{{.Version}}\n")
 }
@@ -6,10 +6,20 @@ import "github.com/spf13/cobra"
 // These reuse the RunE functions from note.go to avoid code duplication.
 
 var createCmd = &cobra.Command{
-	Use:   "create <title>",
-	Short: "Create a new note",
-	Args:  cobra.MinimumNArgs(1),
-	RunE:  noteCreateCmd.RunE,
+	Use:               "create <title>",
+	Short:             "Create a new note",
+	Args:              cobra.MinimumNArgs(1),
+	ValidArgsFunction: completeNothing,
+	RunE:              noteCreateCmd.RunE,
+}
+
+var appendCmd = &cobra.Command{
+	Use:               "append <title> [text...]",
+	Short:             "Append text to a note",
+	Long:              noteAppendCmd.Long,
+	Args:              cobra.MinimumNArgs(1),
+	ValidArgsFunction: completeNoteTitles,
+	RunE:              noteAppendCmd.RunE,
 }
 
 var listCmd = &cobra.Command{
@@ -19,17 +29,19 @@ var listCmd = &cobra.Command{
 }
 
 var showCmd = &cobra.Command{
-	Use:   "show <title>",
-	Short: "Show note content",
-	Args:  cobra.MinimumNArgs(1),
-	RunE:  noteShowCmd.RunE,
+	Use:               "show <title>",
+	Short:             "Show note content",
+	Args:              cobra.MinimumNArgs(1),
+	ValidArgsFunction: completeNoteTitles,
+	RunE:              noteShowCmd.RunE,
 }
 
 var editCmd = &cobra.Command{
-	Use:   "edit <title>",
-	Short: "Edit a note",
-	Args:  cobra.MinimumNArgs(1),
-	RunE:  noteEditCmd.RunE,
+	Use:               "edit <title>",
+	Short:             "Edit a note",
+	Args:              cobra.MinimumNArgs(1),
+	ValidArgsFunction: completeNoteTitles,
+	RunE:              noteEditCmd.RunE,
 }
 
 func init() {
@@ -37,8 +49,18 @@ func init() {
 	rootCmd.AddCommand(listCmd)
 	rootCmd.AddCommand(showCmd)
 	rootCmd.AddCommand(editCmd)
+	rootCmd.AddCommand(appendCmd)
 
 	createCmd.Flags().StringSliceP("tag", "t", []string{}, "tags (can be specified multiple times)")
 	createCmd.Flags().StringP("template", "T", "", "template name")
+	createCmd.Flags().Bool("stdin", false, "read note content from stdin (implies --no-edit)")
+	createCmd.Flags().Bool("no-edit", false, "create the note without opening the editor")
 	listCmd.Flags().StringP("tag", "t", "", "filter by tag")
+	appendCmd.Flags().StringP("heading", "H", "", "append to the end of this section (created if missing)")
+	appendCmd.Flags().Bool("timestamp", false, "prefix the text with the current date and time")
+	listCmd.Flags().String("format", "", "Go template or preset name from list_formats.notes")
+
+	createCmd.RegisterFlagCompletionFunc("tag", completeNoteTags)
+	createCmd.RegisterFlagCompletionFunc("template", completeTemplates)
+	listCmd.RegisterFlagCompletionFunc("tag", completeNoteTags)
 }
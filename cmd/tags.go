@@ -0,0 +1,129 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/intiramisu/note-cli/internal/note"
+	"github.com/mattn/go-runewidth"
+	"github.com/spf13/cobra"
+)
+
+var tagsCmd = &cobra.Command{
+	Use:   "tags",
+	Short: "List note tags with counts",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		sortBy, _ := cmd.Flags().GetString("sort")
+		if sortBy != note.SortTagsByCount && sortBy != note.SortTagsByName {
+			return fmt.Errorf("未対応の並び順: %s (count, name が使えます)", sortBy)
+		}
+
+		storage, err := newStorage()
+		if err != nil {
+			return err
+		}
+		notes, err := storage.List("")
+		if err != nil {
+			return err
+		}
+
+		tags := note.CountTags(notes, sortBy)
+		if structuredOutput() {
+			return printTags(tags)
+		}
+
+		if len(tags) == 0 {
+			fmt.Println("タグがありません")
+			return nil
+		}
+
+		nameWidth, maxCount := 0, 0
+		for _, t := range tags {
+			nameWidth = max(nameWidth, runewidth.StringWidth(t.Name))
+			maxCount = max(maxCount, t.Count)
+		}
+		for _, t := range tags {
+			padding := strings.Repeat(" ", nameWidth-runewidth.StringWidth(t.Name))
+			fmt.Printf("%s%s %s %d\n", t.Name, padding, bar(t.Count, maxCount), t.Count)
+		}
+		return nil
+	},
+}
+
+var tagCmd = &cobra.Command{
+	Use:   "tag",
+	Short: "Rename or merge tags across notes and tasks",
+}
+
+var tagRenameCmd = &cobra.Command{
+	Use:               "rename <old> <new>",
+	Short:             "Rename a tag (and its nested tags) in all notes and tasks",
+	Args:              cobra.ExactArgs(2),
+	ValidArgsFunction: completeNoteTags,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return renameTags(args[:1], args[1])
+	},
+}
+
+var tagMergeCmd = &cobra.Command{
+	Use:               "merge <tag>... --into <tag>",
+	Short:             "Merge tags into one tag in all notes and tasks",
+	Args:              cobra.MinimumNArgs(1),
+	ValidArgsFunction: completeNoteTags,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		into, _ := cmd.Flags().GetString("into")
+		return renameTags(args, into)
+	},
+}
+
+// renameTags rewrites each of sources (and tags nested under it) to target.
+func renameTags(sources []string, target string) error {
+	target = strings.TrimSpace(target)
+	if target == "" {
+		return fmt.Errorf("変更後のタグを指定してください")
+	}
+	for _, src := range sources {
+		if strings.HasPrefix(target, src+"/") {
+			return fmt.Errorf("タグを自身の子に変更できません: %s → %s", src, target)
+		}
+	}
+
+	storage, err := newStorage()
+	if err != nil {
+		return err
+	}
+	manager, err := newTaskManager()
+	if err != nil {
+		return err
+	}
+
+	for _, src := range sources {
+		if src == target {
+			continue
+		}
+		notes, err := storage.RenameTag(src, target)
+		if err != nil {
+			return err
+		}
+		tasks, err := manager.RenameTag(src, target)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("%s → %s: メモ %d 件, タスク %d 件\n", src, target, notes, tasks)
+	}
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(tagsCmd)
+	tagsCmd.Flags().String("sort", note.SortTagsByCount, "sort order (count, name)")
+	tagsCmd.RegisterFlagCompletionFunc("sort", cobra.FixedCompletions(
+		[]string{note.SortTagsByCount + "\t件数の多い順", note.SortTagsByName + "\t名前順"}, cobra.ShellCompDirectiveNoFileComp))
+
+	rootCmd.AddCommand(tagCmd)
+	tagCmd.AddCommand(tagRenameCmd)
+	tagCmd.AddCommand(tagMergeCmd)
+	tagMergeCmd.Flags().String("into", "", "tag to merge into")
+	tagMergeCmd.MarkFlagRequired("into")
+	tagMergeCmd.RegisterFlagCompletionFunc("into", completeNoteTags)
+}
@@ -2,9 +2,10 @@ package cmd
 
 import (
 	"fmt"
-	"strconv"
+	"slices"
 	"strings"
 
+	"github.com/intiramisu/note-cli/internal/config"
 	"github.com/intiramisu/note-cli/internal/task"
 	"github.com/intiramisu/note-cli/internal/util"
 	"github.com/spf13/cobra"
@@ -19,19 +20,27 @@ var taskCmd = &cobra.Command{
 		if err != nil {
 			return err
 		}
-		return task.Run(manager)
+		autoArchive(manager)
+		storage, err := newStorage()
+		if err != nil {
+			return err
+		}
+		return task.Run(manager, storage)
 	},
 }
 
 var taskAddCmd = &cobra.Command{
-	Use:   "add <description>",
-	Short: "Add a new task",
-	Args:  cobra.MinimumNArgs(1),
+	Use:               "add <description>",
+	Short:             "Add a new task",
+	Args:              cobra.MinimumNArgs(1),
+	ValidArgsFunction: completeNothing,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		description := strings.Join(args, " ")
 		priorityStr, _ := cmd.Flags().GetString("priority")
 		noteID, _ := cmd.Flags().GetString("note")
 		dueStr, _ := cmd.Flags().GetString("due")
+		project, _ := cmd.Flags().GetString("project")
+		tags, _ := cmd.Flags().GetStringSlice("tag")
 
 		priority := task.ParsePriority(priorityStr)
 
@@ -44,8 +53,12 @@ var taskAddCmd = &cobra.Command{
 		if err != nil {
 			return err
 		}
+		autoArchive(manager)
 
-		t := manager.Add(description, priority, noteID, dueDate)
+		t, err := manager.AddWith(description, priority, noteID, dueDate, task.AddOptions{Project: project, Tags: tags})
+		if err != nil {
+			return err
+		}
 
 		// 出力メッセージを構築
 		var extras []string
@@ -65,11 +78,25 @@ var taskAddCmd = &cobra.Command{
 }
 
 var taskListCmd = &cobra.Command{
-	Use:   "list",
+	Use:   "list [query]",
 	Short: "List tasks",
+	Example: `  note-cli task list 'priority>=P2 and due<+7d and note:"週次MTG" and not done'
+  note-cli task list 'tag:work or project:none'
+  note-cli task list --view week`,
+	ValidArgsFunction: completeNothing,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		showAll, _ := cmd.Flags().GetBool("all")
 		sortByDue, _ := cmd.Flags().GetBool("due")
+		archived, _ := cmd.Flags().GetBool("archived")
+		format, _ := cmd.Flags().GetString("format")
+		filter, err := taskFilterFromFlags(cmd, strings.Join(args, " "))
+		if err != nil {
+			return err
+		}
+		// done / pending を条件に含むクエリは完了済みも対象にする
+		if filter.Query.ChecksStatus() {
+			showAll = true
+		}
 
 		manager, err := newTaskManager()
 		if err != nil {
@@ -77,12 +104,45 @@ var taskListCmd = &cobra.Command{
 		}
 
 		var tasks []*task.Task
-		if sortByDue {
+		switch {
+		case archived:
+			tasks, err = manager.ListArchived()
+			if err != nil {
+				return err
+			}
+		case sortByDue:
 			tasks = manager.ListByDueDate(showAll)
-		} else {
+		default:
 			tasks = manager.List(showAll)
 		}
 
+		if !filter.IsZero() {
+			var matched []*task.Task
+			for _, t := range tasks {
+				if filter.Match(t) {
+					matched = append(matched, t)
+				}
+			}
+			tasks = matched
+		}
+
+		if format != "" {
+			tmpl, err := listTemplate(format, config.Global.ListFormats.Tasks)
+			if err != nil {
+				return err
+			}
+			for _, t := range tasks {
+				if err := printWithTemplate(tmpl, t); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+
+		if structuredOutput() {
+			return printTasks(tasks)
+		}
+
 		if len(tasks) == 0 {
 			fmt.Println("タスクがありません")
 			return nil
@@ -110,7 +170,14 @@ var taskListCmd = &cobra.Command{
 					dueStr = fmt.Sprintf(" 📅 %s", dueLabel)
 				}
 			}
-			fmt.Printf("%s [%d]%s %s%s%s\n", checkbox, t.ID, priorityStr, t.Description, noteStr, dueStr)
+			labelStr := ""
+			if t.Project != "" {
+				labelStr += fmt.Sprintf(" 📁 %s", t.Project)
+			}
+			if len(t.Tags) > 0 {
+				labelStr += fmt.Sprintf(" 🏷 %s", strings.Join(t.Tags, ", "))
+			}
+			fmt.Printf("%s [%d]%s %s%s%s%s\n", checkbox, t.ID, priorityStr, t.Description, noteStr, dueStr, labelStr)
 		}
 
 		return nil
@@ -118,38 +185,60 @@ var taskListCmd = &cobra.Command{
 }
 
 var taskDoneCmd = &cobra.Command{
-	Use:   "done <id>",
-	Short: "Mark a task as done",
-	Args:  cobra.ExactArgs(1),
+	Use:               "done [<id>|<from>-<to>]...",
+	Short:             "Mark tasks as done",
+	Example:           "  note-cli task done 3 5 7-10\n  note-cli task done --overdue --tag work",
+	ValidArgsFunction: taskIDCompletion(false),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		id, err := strconv.Atoi(args[0])
+		manager, err := newTaskManager()
 		if err != nil {
-			return fmt.Errorf("無効なID: %s", args[0])
+			return err
 		}
+		autoArchive(manager)
 
-		manager, err := newTaskManager()
+		tasks, sel, err := selectTasks(cmd, manager, args)
 		if err != nil {
 			return err
 		}
 
-		if err := manager.Done(id); err != nil {
-			return err
+		var pending []*task.Task
+		for _, t := range tasks {
+			switch {
+			case !t.IsDone():
+				pending = append(pending, t)
+			case slices.Contains(sel.IDs, t.ID):
+				fmt.Printf("タスクは完了済みです: [%d] %s\n", t.ID, t.Description)
+			}
+		}
+		if len(pending) == 0 {
+			if len(tasks) == 0 {
+				fmt.Println("該当するタスクがありません")
+			}
+			return nil
 		}
 
-		t, _ := manager.Get(id)
-		fmt.Printf("タスクを完了しました: [%d] %s\n", t.ID, t.Description)
+		if err := manager.UpdateAll(taskIDList(pending), (*task.Task).Done); err != nil {
+			return err
+		}
+		for _, t := range pending {
+			fmt.Printf("タスクを完了しました: [%d] %s\n", t.ID, t.Description)
+		}
 		return nil
 	},
 }
 
-var taskDeleteCmd = &cobra.Command{
-	Use:   "delete <id>",
-	Short: "Delete a task",
-	Args:  cobra.ExactArgs(1),
+var taskArchiveCmd = &cobra.Command{
+	Use:   "archive",
+	Short: "Move completed tasks to the archive",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		id, err := strconv.Atoi(args[0])
+		olderThanStr, _ := cmd.Flags().GetString("older-than")
+		if !cmd.Flags().Changed("older-than") {
+			olderThanStr = config.Global.Archive.OlderThan
+		}
+
+		olderThan, err := util.ParsePeriod(olderThanStr)
 		if err != nil {
-			return fmt.Errorf("無効なID: %s", args[0])
+			return err
 		}
 
 		manager, err := newTaskManager()
@@ -157,31 +246,185 @@ var taskDeleteCmd = &cobra.Command{
 			return err
 		}
 
-		t, err := manager.Get(id)
+		count, err := manager.Archive(olderThan)
+		if err != nil {
+			return err
+		}
+
+		if count == 0 {
+			fmt.Println("アーカイブ対象のタスクがありません")
+			return nil
+		}
+		fmt.Printf("%d 件のタスクをアーカイブしました\n", count)
+		return nil
+	},
+}
+
+var taskDeleteCmd = &cobra.Command{
+	Use:               "delete [<id>|<from>-<to>]...",
+	Short:             "Delete tasks",
+	Example:           "  note-cli task delete 3\n  note-cli task delete 7-10 --tag old",
+	ValidArgsFunction: taskIDCompletion(true),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		force, _ := cmd.Flags().GetBool("force")
+
+		manager, err := newTaskManager()
 		if err != nil {
 			return err
 		}
-		desc := t.Description
+		autoArchive(manager)
 
-		if err := manager.Delete(id); err != nil {
+		tasks, _, err := selectTasks(cmd, manager, args)
+		if err != nil {
 			return err
 		}
+		if len(tasks) == 0 {
+			fmt.Println("該当するタスクがありません")
+			return nil
+		}
 
-		fmt.Printf("タスクを削除しました: [%d] %s\n", id, desc)
+		// 複数件の削除は確認する
+		if len(tasks) > 1 && !force {
+			for _, t := range tasks {
+				fmt.Printf("  [%d] %s\n", t.ID, t.Description)
+			}
+			fmt.Printf("%d 件のタスクを削除しますか？ [y/N]: ", len(tasks))
+			var answer string
+			fmt.Scanln(&answer)
+			if strings.ToLower(answer) != "y" {
+				fmt.Println("キャンセルしました")
+				return nil
+			}
+		}
+
+		if err := manager.DeleteAll(taskIDList(tasks)); err != nil {
+			return err
+		}
+		for _, t := range tasks {
+			fmt.Printf("タスクを削除しました: [%d] %s\n", t.ID, t.Description)
+		}
 		return nil
 	},
 }
 
+// addTaskFilterFlags adds the flags that narrow tasks by their attributes.
+func addTaskFilterFlags(cmd *cobra.Command) {
+	cmd.Flags().Bool("overdue", false, "only overdue tasks")
+	cmd.Flags().StringSliceP("tag", "t", []string{}, "only tasks with this tag (can be specified multiple times)")
+	cmd.Flags().StringP("project", "P", "", "only tasks in this project")
+	cmd.Flags().StringP("priority", "p", "", "only tasks with this priority (1/high, 2/medium, 3/low)")
+	cmd.Flags().StringP("note", "n", "", "only tasks linked to this note")
+	cmd.Flags().StringP("where", "w", "", `only tasks matching this query (e.g. "priority>=P2 and due<+7d")`)
+	cmd.Flags().String("view", "", "only tasks matching this view from task_views")
+	cmd.RegisterFlagCompletionFunc("tag", completeTaskTags)
+	cmd.RegisterFlagCompletionFunc("view", completeTaskViews)
+	cmd.RegisterFlagCompletionFunc("project", completeTaskProjects)
+	cmd.RegisterFlagCompletionFunc("note", completeNotes)
+	cmd.RegisterFlagCompletionFunc("priority", cobra.FixedCompletions(
+		[]string{"1\thigh", "2\tmedium", "3\tlow"}, cobra.ShellCompDirectiveNoFileComp))
+}
+
+// taskFilterFromFlags builds a filter from the filter flags. --view, --where
+// and query are all required to match.
+func taskFilterFromFlags(cmd *cobra.Command, query string) (task.Filter, error) {
+	overdue, _ := cmd.Flags().GetBool("overdue")
+	tags, _ := cmd.Flags().GetStringSlice("tag")
+	project, _ := cmd.Flags().GetString("project")
+	priorityStr, _ := cmd.Flags().GetString("priority")
+	noteID, _ := cmd.Flags().GetString("note")
+	where, _ := cmd.Flags().GetString("where")
+	view, _ := cmd.Flags().GetString("view")
+
+	priority := task.ParsePriority(priorityStr)
+	if priorityStr != "" && priority == task.PriorityNone {
+		return task.Filter{}, fmt.Errorf("無効な優先度: %s", priorityStr)
+	}
+	filter := task.Filter{Overdue: overdue, Tags: tags, Project: project, Priority: priority, NoteID: noteID}
+
+	var queries []*task.Query
+	if view != "" {
+		viewQuery, ok := config.Global.TaskViews[view]
+		if !ok {
+			return task.Filter{}, fmt.Errorf("ビューが見つかりません: %s", view)
+		}
+		q, err := task.ParseQuery(viewQuery)
+		if err != nil {
+			return task.Filter{}, fmt.Errorf("ビュー %s: %w", view, err)
+		}
+		queries = append(queries, q)
+	}
+	for _, s := range []string{where, query} {
+		if strings.TrimSpace(s) == "" {
+			continue
+		}
+		q, err := task.ParseQuery(s)
+		if err != nil {
+			return task.Filter{}, err
+		}
+		queries = append(queries, q)
+	}
+	for _, q := range queries {
+		if filter.Query == nil {
+			filter.Query = q
+		} else {
+			filter.Query = filter.Query.And(q)
+		}
+	}
+	return filter, nil
+}
+
+// selectTasks returns the tasks chosen by ID arguments (ranges allowed) and
+// filter flags. At least one of them is required.
+func selectTasks(cmd *cobra.Command, manager *task.Manager, args []string) ([]*task.Task, task.IDSelection, error) {
+	sel, err := task.ParseIDs(args)
+	if err != nil {
+		return nil, sel, err
+	}
+	filter, err := taskFilterFromFlags(cmd, "")
+	if err != nil {
+		return nil, sel, err
+	}
+	if sel.IsEmpty() && filter.IsZero() {
+		return nil, sel, fmt.Errorf("タスクIDまたは絞り込み条件を指定してください")
+	}
+	tasks, err := manager.Select(sel, filter)
+	return tasks, sel, err
+}
+
+func taskIDList(tasks []*task.Task) []int {
+	ids := make([]int, len(tasks))
+	for i, t := range tasks {
+		ids[i] = t.ID
+	}
+	return ids
+}
+
 func init() {
 	rootCmd.AddCommand(taskCmd)
 	taskCmd.AddCommand(taskAddCmd)
 	taskCmd.AddCommand(taskListCmd)
 	taskCmd.AddCommand(taskDoneCmd)
 	taskCmd.AddCommand(taskDeleteCmd)
+	taskCmd.AddCommand(taskArchiveCmd)
 
 	taskAddCmd.Flags().StringP("priority", "p", "", "priority (1/high, 2/medium, 3/low)")
 	taskAddCmd.Flags().StringP("note", "n", "", "link to a note")
 	taskAddCmd.Flags().StringP("due", "d", "", "due date (2006-01-02, tomorrow, +3)")
+	taskAddCmd.Flags().StringP("project", "P", "", "project name")
+	taskAddCmd.Flags().StringSliceP("tag", "t", []string{}, "tags (can be specified multiple times)")
 	taskListCmd.Flags().BoolP("all", "a", false, "show completed tasks too")
 	taskListCmd.Flags().BoolP("due", "d", false, "sort by due date")
+	taskListCmd.Flags().Bool("archived", false, "show archived tasks")
+	taskListCmd.Flags().String("format", "", "Go template or preset name from list_formats.tasks")
+	taskAddCmd.RegisterFlagCompletionFunc("note", completeNotes)
+	taskAddCmd.RegisterFlagCompletionFunc("due", completeDueDate)
+	taskAddCmd.RegisterFlagCompletionFunc("project", completeTaskProjects)
+	taskAddCmd.RegisterFlagCompletionFunc("tag", completeTaskTags)
+	taskAddCmd.RegisterFlagCompletionFunc("priority", cobra.FixedCompletions(
+		[]string{"1\thigh", "2\tmedium", "3\tlow"}, cobra.ShellCompDirectiveNoFileComp))
+	addTaskFilterFlags(taskListCmd)
+	addTaskFilterFlags(taskDoneCmd)
+	addTaskFilterFlags(taskDeleteCmd)
+	taskDeleteCmd.Flags().BoolP("force", "f", false, "delete several tasks without confirmation")
+	taskArchiveCmd.Flags().String("older-than", "", "archive tasks completed before this period (e.g. 14d, 2w; default: archive.older_than)")
 }
@@ -0,0 +1,116 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/intiramisu/note-cli/internal/task"
+	"github.com/spf13/cobra"
+)
+
+var taskExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export tasks to another format",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		format, _ := cmd.Flags().GetString("format")
+		output, _ := cmd.Flags().GetString("file")
+		withEvents, _ := cmd.Flags().GetBool("events")
+
+		manager, err := newTaskManager()
+		if err != nil {
+			return err
+		}
+
+		var w io.Writer = os.Stdout
+		if output != "" && output != "-" {
+			f, err := os.Create(output)
+			if err != nil {
+				return fmt.Errorf("出力ファイルの作成に失敗: %w", err)
+			}
+			defer f.Close()
+			w = f
+		}
+
+		tasks := manager.List(true)
+		switch format {
+		case "todotxt":
+			err = task.WriteTodoTxt(w, tasks)
+		case "ics":
+			err = task.WriteICS(w, tasks, withEvents)
+		case "taskwarrior":
+			err = task.WriteTaskwarrior(w, tasks)
+		default:
+			return fmt.Errorf("未対応のフォーマット: %s (todotxt, ics, taskwarrior が使えます)", format)
+		}
+		if err != nil {
+			return fmt.Errorf("エクスポートに失敗: %w", err)
+		}
+
+		if output != "" && output != "-" {
+			fmt.Printf("%d 件のタスクをエクスポートしました: %s\n", len(tasks), output)
+		}
+		return nil
+	},
+}
+
+var taskImportCmd = &cobra.Command{
+	Use:   "import <file>",
+	Short: "Import tasks from another format",
+	Long: `Import tasks from a file ("-" for stdin).
+Tasks that already exist (same UUID, ID or description) are updated instead of duplicated.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		format, _ := cmd.Flags().GetString("format")
+
+		var r io.Reader = os.Stdin
+		if args[0] != "-" {
+			f, err := os.Open(args[0])
+			if err != nil {
+				return fmt.Errorf("ファイルを開けません: %w", err)
+			}
+			defer f.Close()
+			r = f
+		}
+
+		var tasks []*task.Task
+		var err error
+		switch format {
+		case "todotxt":
+			tasks, err = task.ReadTodoTxt(r)
+		case "ics":
+			tasks, err = task.ReadICS(r)
+		case "taskwarrior":
+			tasks, err = task.ReadTaskwarrior(r)
+		default:
+			return fmt.Errorf("未対応のフォーマット: %s (todotxt, ics, taskwarrior が使えます)", format)
+		}
+		if err != nil {
+			return err
+		}
+
+		manager, err := newTaskManager()
+		if err != nil {
+			return err
+		}
+		autoArchive(manager)
+
+		added, updated, err := manager.Import(tasks)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("インポートしました: 追加 %d 件, 更新 %d 件\n", added, updated)
+		return nil
+	},
+}
+
+func init() {
+	taskCmd.AddCommand(taskExportCmd)
+	taskCmd.AddCommand(taskImportCmd)
+
+	taskExportCmd.Flags().StringP("format", "f", "todotxt", "export format (todotxt, ics, taskwarrior)")
+	taskExportCmd.Flags().String("file", "", "output file (default: stdout)")
+	taskExportCmd.Flags().Bool("events", false, "also export due dates as all-day events (ics only)")
+	taskImportCmd.Flags().StringP("format", "f", "todotxt", "import format (todotxt, ics, taskwarrior)")
+}
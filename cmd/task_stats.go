@@ -0,0 +1,211 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/intiramisu/note-cli/internal/task"
+	"github.com/spf13/cobra"
+)
+
+var taskStatsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Show task productivity statistics",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		days, _ := cmd.Flags().GetInt("days")
+		format, _ := cmd.Flags().GetString("format")
+
+		storage, err := newStorage()
+		if err != nil {
+			return err
+		}
+
+		manager, err := newTaskManager()
+		if err != nil {
+			return err
+		}
+
+		stats, err := manager.Stats(days)
+		if err != nil {
+			return err
+		}
+
+		// メモIDをタイトルに解決
+		noteTitles := make(map[string]string)
+		for i := range stats.ByNote {
+			stats.ByNote[i].Key = resolveNoteTitle(storage, noteTitles, stats.ByNote[i].Key)
+		}
+
+		switch format {
+		case "json":
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "  ")
+			return enc.Encode(stats)
+		case "csv":
+			return writeStatsCSV(stats)
+		case "", "text":
+			printStats(stats, days)
+			return nil
+		default:
+			return fmt.Errorf("未対応のフォーマット: %s (text, json, csv が使えます)", format)
+		}
+	},
+}
+
+// statsBarWidth is the maximum width of bars in the terminal chart.
+const statsBarWidth = 30
+
+func printStats(s task.Stats, days int) {
+	fmt.Printf("📊 タスク統計 (直近%d日)\n", days)
+	fmt.Printf("合計: %d | 完了: %d | 未完了: %d\n", s.Total, s.Completed, s.Pending)
+	fmt.Printf("平均リードタイム: %s\n", formatLeadTime(s.AverageLeadTime))
+	if s.WithDueDate > 0 {
+		fmt.Printf("期限超過率: %.0f%% (%d/%d)\n", s.OverdueRate*100, s.Late, s.WithDueDate)
+	} else {
+		fmt.Println("期限超過率: - (期限付きタスクなし)")
+	}
+
+	maxDay := 0
+	for _, d := range s.CompletedPerDay {
+		maxDay = max(maxDay, d.Count)
+	}
+	fmt.Println("\n完了数 (日別)")
+	for _, d := range s.CompletedPerDay {
+		fmt.Printf("  %s %s %d\n", d.Date.Format("01/02"), bar(d.Count, maxDay), d.Count)
+	}
+
+	maxWeek := 0
+	for _, w := range s.CompletedPerWeek {
+		maxWeek = max(maxWeek, w.Count)
+	}
+	fmt.Println("\n完了数 (週別)")
+	for _, w := range s.CompletedPerWeek {
+		fmt.Printf("  %s〜 %s %d\n", w.Date.Format("01/02"), bar(w.Count, maxWeek), w.Count)
+	}
+
+	fmt.Println("\nヒートマップ")
+	fmt.Print(heatmap(s.CompletedPerDay, maxDay))
+
+	if len(s.ByPriority) > 0 {
+		fmt.Println("\n優先度別 (完了/合計)")
+		for _, g := range s.ByPriority {
+			fmt.Printf("  %-2s %s %d/%d\n", g.Key, ratioBar(g.Completed, g.Total), g.Completed, g.Total)
+		}
+	}
+
+	if len(s.ByNote) > 0 {
+		fmt.Println("\nメモ別 (完了/合計)")
+		for _, g := range s.ByNote {
+			fmt.Printf("  %s %d/%d %s\n", ratioBar(g.Completed, g.Total), g.Completed, g.Total, g.Key)
+		}
+	}
+}
+
+func formatLeadTime(d time.Duration) string {
+	if d == 0 {
+		return "-"
+	}
+	days := int(d.Hours()) / 24
+	hours := int(d.Hours()) % 24
+	if days > 0 {
+		return fmt.Sprintf("%d日 %d時間", days, hours)
+	}
+	if hours > 0 {
+		return fmt.Sprintf("%d時間", hours)
+	}
+	return fmt.Sprintf("%d分", int(d.Minutes()))
+}
+
+func bar(n, maxN int) string {
+	if maxN == 0 {
+		return ""
+	}
+	return strings.Repeat("█", n*statsBarWidth/maxN)
+}
+
+func ratioBar(done, total int) string {
+	const width = 10
+	filled := 0
+	if total > 0 {
+		filled = done * width / total
+	}
+	return strings.Repeat("█", filled) + strings.Repeat("░", width-filled)
+}
+
+// heatmap renders completions as a weekday x week grid.
+func heatmap(days []task.DayCount, maxN int) string {
+	if len(days) == 0 {
+		return ""
+	}
+	shades := []string{"·", "░", "▒", "▓", "█"}
+	weekdays := []string{"月", "火", "水", "木", "金", "土", "日"}
+
+	// 各日を (曜日, 週) のマスに配置する
+	first := days[0].Date
+	offset := (int(first.Weekday()) + 6) % 7
+	weeks := (offset + len(days) + 6) / 7
+	grid := make([][]string, 7)
+	for i := range grid {
+		grid[i] = make([]string, weeks)
+		for j := range grid[i] {
+			grid[i][j] = " "
+		}
+	}
+	for i, d := range days {
+		cell := offset + i
+		level := 0
+		if d.Count > 0 && maxN > 0 {
+			level = min(1+d.Count*(len(shades)-2)/maxN, len(shades)-1)
+		}
+		grid[cell%7][cell/7] = shades[level]
+	}
+
+	var b strings.Builder
+	for i, row := range grid {
+		b.WriteString("  " + weekdays[i] + " " + strings.Join(row, "") + "\n")
+	}
+	return b.String()
+}
+
+func writeStatsCSV(s task.Stats) error {
+	w := csv.NewWriter(os.Stdout)
+	w.Write([]string{"metric", "key", "value"})
+
+	itoa := strconv.Itoa
+	ftoa := func(f float64) string { return strconv.FormatFloat(f, 'f', 2, 64) }
+
+	w.Write([]string{"total", "", itoa(s.Total)})
+	w.Write([]string{"completed", "", itoa(s.Completed)})
+	w.Write([]string{"pending", "", itoa(s.Pending)})
+	w.Write([]string{"average_lead_time_hours", "", ftoa(s.LeadTimeHours)})
+	w.Write([]string{"overdue_rate", "", ftoa(s.OverdueRate)})
+	for _, d := range s.CompletedPerDay {
+		w.Write([]string{"completed_per_day", d.Date.Format("2006-01-02"), itoa(d.Count)})
+	}
+	for _, d := range s.CompletedPerWeek {
+		w.Write([]string{"completed_per_week", d.Date.Format("2006-01-02"), itoa(d.Count)})
+	}
+	for _, g := range s.ByPriority {
+		w.Write([]string{"priority_total", g.Key, itoa(g.Total)})
+		w.Write([]string{"priority_completed", g.Key, itoa(g.Completed)})
+	}
+	for _, g := range s.ByNote {
+		w.Write([]string{"note_total", g.Key, itoa(g.Total)})
+		w.Write([]string{"note_completed", g.Key, itoa(g.Completed)})
+	}
+
+	w.Flush()
+	return w.Error()
+}
+
+func init() {
+	taskCmd.AddCommand(taskStatsCmd)
+
+	taskStatsCmd.Flags().Int("days", 30, "number of days covered by daily/weekly counts")
+	taskStatsCmd.Flags().StringP("format", "f", "text", "output format (text, json, csv)")
+}
@@ -4,19 +4,26 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/spf13/viper"
 )
 
 // Config はアプリケーション全体の設定を保持する
 type Config struct {
-	NotesDir    string   `mapstructure:"notes_dir"`
-	Editor      string   `mapstructure:"editor"`
-	DefaultTags []string `mapstructure:"default_tags"`
-	Paths       Paths    `mapstructure:"paths"`
-	Formats     Formats  `mapstructure:"formats"`
-	Theme       Theme    `mapstructure:"theme"`
-	Display     Display  `mapstructure:"display"`
+	NotesDir    string            `mapstructure:"notes_dir"`
+	Editor      string            `mapstructure:"editor"`
+	DefaultTags []string          `mapstructure:"default_tags"`
+	Paths       Paths             `mapstructure:"paths"`
+	Formats     Formats           `mapstructure:"formats"`
+	Theme       Theme             `mapstructure:"theme"`
+	Display     Display           `mapstructure:"display"`
+	Board       Board             `mapstructure:"board"`
+	Archive     Archive           `mapstructure:"archive"`
+	ListFormats ListFormats       `mapstructure:"list_formats"`
+	TaskViews   map[string]string `mapstructure:"task_views"` // 名前 → タスクの絞り込みクエリ
+	Inbox       Inbox             `mapstructure:"inbox"`
+	Keys        Keys              `mapstructure:"keys"`
 }
 
 // Paths はパス関連の設定
@@ -24,6 +31,7 @@ type Paths struct {
 	TemplatesDir string `mapstructure:"templates_dir"`
 	TasksFile    string `mapstructure:"tasks_file"`
 	DailyDir     string `mapstructure:"daily_dir"`
+	ArchiveDir   string `mapstructure:"archive_dir"`
 }
 
 // Formats は日付フォーマットの設定
@@ -49,6 +57,7 @@ type Colors struct {
 	PriorityHigh   string `mapstructure:"priority_high"`
 	PriorityMedium string `mapstructure:"priority_medium"`
 	PriorityLow    string `mapstructure:"priority_low"`
+	Match          string `mapstructure:"match"`
 }
 
 // Symbols はシンボル設定
@@ -57,6 +66,7 @@ type Symbols struct {
 	CursorEmpty   string `mapstructure:"cursor_empty"`
 	CheckboxEmpty string `mapstructure:"checkbox_empty"`
 	CheckboxDone  string `mapstructure:"checkbox_done"`
+	Marked        string `mapstructure:"marked"`
 	NoteIcon      string `mapstructure:"note_icon"`
 	TaskIcon      string `mapstructure:"task_icon"`
 	DailyIcon     string `mapstructure:"daily_icon"`
@@ -76,6 +86,47 @@ type Display struct {
 	TaskCharLimit  int    `mapstructure:"task_char_limit"`
 	InputWidth     int    `mapstructure:"input_width"`
 	MarkdownStyle  string `mapstructure:"markdown_style"`
+	Preview        bool   `mapstructure:"preview"`
+	PreviewWidth   int    `mapstructure:"preview_min_width"` // これより狭い画面ではプレビューを出さない
+	Mouse          bool   `mapstructure:"mouse"`             // TUIでマウス操作を受け付ける
+	AgendaDays     int    `mapstructure:"agenda_days"`       // アジェンダで「今週」として扱う日数
+}
+
+// Board はタスクTUIのカンバン列設定
+type Board struct {
+	GroupBy string        `mapstructure:"group_by"` // priority | status | project | tag
+	Columns []BoardColumn `mapstructure:"columns"`
+}
+
+// BoardColumn はカンバンの列定義
+type BoardColumn struct {
+	Name  string `mapstructure:"name"`
+	Value string `mapstructure:"value"`
+	Color string `mapstructure:"color"`
+}
+
+// Archive は完了タスクのアーカイブ設定
+type Archive struct {
+	Auto      bool   `mapstructure:"auto"`       // 起動時に自動でアーカイブする
+	OlderThan string `mapstructure:"older_than"` // 完了からこの期間が経ったタスクが対象 (例: 14d)
+}
+
+// ListFormats は list --format で使える名前付きテンプレート
+type ListFormats struct {
+	Notes map[string]string `mapstructure:"notes"`
+	Tasks map[string]string `mapstructure:"tasks"`
+}
+
+// Inbox はクイックキャプチャ (inbox コマンド) の設定
+type Inbox struct {
+	Note    string `mapstructure:"note"`    // 追記先のメモ (空なら今日のデイリーノート)
+	Heading string `mapstructure:"heading"` // 追記先の見出し
+}
+
+// Keys はTUIのキー割り当ての上書き (操作名 → キーのリスト、空リストで無効化)
+type Keys struct {
+	UI   map[string][]string `mapstructure:"ui"`   // 統合TUI
+	Task map[string][]string `mapstructure:"task"` // タスクTUI
 }
 
 // Global は現在の設定を保持するグローバル変数
@@ -94,6 +145,7 @@ func SetDefaults() {
 	viper.SetDefault("paths.templates_dir", ".templates")
 	viper.SetDefault("paths.tasks_file", ".tasks.yaml")
 	viper.SetDefault("paths.daily_dir", "daily")
+	viper.SetDefault("paths.archive_dir", ".archive")
 
 	// フォーマット設定
 	viper.SetDefault("formats.date", "2006-01-02")
@@ -108,12 +160,14 @@ func SetDefaults() {
 	viper.SetDefault("theme.colors.priority_high", "#ff0000")
 	viper.SetDefault("theme.colors.priority_medium", "#ffaf00")
 	viper.SetDefault("theme.colors.priority_low", "#5fafff")
+	viper.SetDefault("theme.colors.match", "#ffd75f")
 
 	// テーマ - シンボル
 	viper.SetDefault("theme.symbols.cursor", "▸ ")
 	viper.SetDefault("theme.symbols.cursor_empty", "  ")
 	viper.SetDefault("theme.symbols.checkbox_empty", "[ ]")
 	viper.SetDefault("theme.symbols.checkbox_done", "[✓]")
+	viper.SetDefault("theme.symbols.marked", "* ")
 	viper.SetDefault("theme.symbols.note_icon", "📄")
 	viper.SetDefault("theme.symbols.task_icon", "📋")
 	viper.SetDefault("theme.symbols.daily_icon", "📅")
@@ -129,6 +183,31 @@ func SetDefaults() {
 	viper.SetDefault("display.task_char_limit", 100)
 	viper.SetDefault("display.input_width", 40)
 	viper.SetDefault("display.markdown_style", "dark")
+	viper.SetDefault("display.preview", true)
+	viper.SetDefault("display.preview_min_width", 100)
+	viper.SetDefault("display.mouse", true)
+	viper.SetDefault("display.agenda_days", 7)
+
+	// カンバン設定
+	viper.SetDefault("board.group_by", "priority")
+
+	// アーカイブ設定
+	viper.SetDefault("archive.auto", false)
+	viper.SetDefault("archive.older_than", "14d")
+
+	// 一覧の出力テンプレート
+	viper.SetDefault("list_formats.notes.titles", "{{.Title}}")
+	viper.SetDefault("list_formats.notes.tags", `{{.Title}}\t{{join .Tags ","}}`)
+	viper.SetDefault("list_formats.tasks.due", `{{.ID}}\t{{relative .DueDate}}\t{{.Description}}`)
+
+	// タスクの名前付き絞り込み (ビュー)
+	viper.SetDefault("task_views.today", "due<=today and not done")
+	viper.SetDefault("task_views.week", "due<=+7d and not done")
+	viper.SetDefault("task_views.unlinked", "note:none and not done")
+
+	// インボックス設定
+	viper.SetDefault("inbox.note", "")
+	viper.SetDefault("inbox.heading", "メモ")
 }
 
 // Load は設定を読み込んでグローバル変数に格納する
@@ -165,3 +244,13 @@ func (c *Config) GetTasksPath() string {
 func (c *Config) GetDailyPath() string {
 	return filepath.Join(c.NotesDir, c.Paths.DailyDir)
 }
+
+// GetArchivePath はタスクアーカイブディレクトリの絶対パスを返す
+func (c *Config) GetArchivePath() string {
+	return filepath.Join(c.NotesDir, c.Paths.ArchiveDir)
+}
+
+// GetDailyNoteID はデイリーノートのメモID (notes_dir からの相対パス) を返す
+func (c *Config) GetDailyNoteID(date time.Time) string {
+	return filepath.Join(c.Paths.DailyDir, date.Format(c.Formats.Date)+".md")
+}
@@ -4,6 +4,7 @@ import (
 	"os"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/spf13/viper"
 )
@@ -76,12 +77,67 @@ func TestLoad(t *testing.T) {
 		t.Errorf("Global.Paths.TasksFile = %q, want %q", Global.Paths.TasksFile, ".tasks.yaml")
 	}
 
+	if Global.Paths.ArchiveDir != ".archive" {
+		t.Errorf("Global.Paths.ArchiveDir = %q, want %q", Global.Paths.ArchiveDir, ".archive")
+	}
+
+	if Global.Archive.Auto || Global.Archive.OlderThan != "14d" {
+		t.Errorf("Global.Archive = %+v, want {Auto:false OlderThan:14d}", Global.Archive)
+	}
+
+	if Global.Board.GroupBy != "priority" {
+		t.Errorf("Global.Board.GroupBy = %q, want %q", Global.Board.GroupBy, "priority")
+	}
+
+	if Global.Inbox.Note != "" || Global.Inbox.Heading != "メモ" {
+		t.Errorf("Global.Inbox = %+v, want {Note: Heading:メモ}", Global.Inbox)
+	}
+
+	if Global.ListFormats.Notes["titles"] != "{{.Title}}" || Global.ListFormats.Tasks["due"] == "" {
+		t.Errorf("Global.ListFormats = %+v, want default presets", Global.ListFormats)
+	}
+
+	if Global.TaskViews["week"] != "due<=+7d and not done" {
+		t.Errorf("Global.TaskViews = %+v, want default views", Global.TaskViews)
+	}
+
 	// NotesDir should be expanded (no ~/)
 	if strings.HasPrefix(Global.NotesDir, "~/") {
 		t.Errorf("NotesDir should be expanded, got %q", Global.NotesDir)
 	}
 }
 
+func TestLoadKeys(t *testing.T) {
+	viper.Reset()
+	SetDefaults()
+	viper.SetConfigType("yaml")
+	err := viper.ReadConfig(strings.NewReader(`
+keys:
+  ui:
+    delete_task: []
+    quit: Q
+  task:
+    delete: [X, ctrl+x]
+`))
+	if err != nil {
+		t.Fatalf("ReadConfig() error = %v", err)
+	}
+	if err := Load(); err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	keys, ok := Global.Keys.UI["delete_task"]
+	if !ok || len(keys) != 0 {
+		t.Errorf("Keys.UI[delete_task] = %v (ok=%v), want empty list", keys, ok)
+	}
+	if got := Global.Keys.UI["quit"]; len(got) != 1 || got[0] != "Q" {
+		t.Errorf("Keys.UI[quit] = %v, want [Q]", got)
+	}
+	if got := Global.Keys.Task["delete"]; len(got) != 2 || got[0] != "X" {
+		t.Errorf("Keys.Task[delete] = %v, want [X ctrl+x]", got)
+	}
+}
+
 func TestExpandTilde(t *testing.T) {
 	home, _ := os.UserHomeDir()
 
@@ -116,3 +172,14 @@ func TestExpandTilde(t *testing.T) {
 		})
 	}
 }
+
+func TestGetDailyNoteID(t *testing.T) {
+	c := &Config{
+		Paths:   Paths{DailyDir: "daily"},
+		Formats: Formats{Date: "2006-01-02"},
+	}
+	date := time.Date(2026, 1, 10, 12, 0, 0, 0, time.Local)
+	if got := c.GetDailyNoteID(date); got != "daily/2026-01-10.md" {
+		t.Errorf("GetDailyNoteID() = %q, want %q", got, "daily/2026-01-10.md")
+	}
+}
@@ -0,0 +1,136 @@
+package note
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+)
+
+var headingRe = regexp.MustCompile(`^(#{1,6})\s+(.*?)\s*#*\s*$`)
+
+// Append adds text to a note file. If heading is given, the text goes to the
+// end of that section (the heading is created when missing); otherwise it goes
+// to the end of the note. The frontmatter's modified time is updated.
+func (s *Storage) Append(filename, text, heading string) error {
+	path := filepath.Join(s.notesDir, filename)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("メモの読み込みに失敗: %w", err)
+	}
+
+	content := touchModified(string(data), time.Now())
+	content = appendToSection(content, heading, text)
+
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		return fmt.Errorf("メモの保存に失敗: %w", err)
+	}
+	return nil
+}
+
+// appendToSection inserts text at the end of the section under heading.
+// heading may be given with or without leading "#"s ("メモ" or "## メモ").
+func appendToSection(content, heading, text string) string {
+	text = strings.TrimRight(text, "\n")
+	lines := strings.Split(strings.TrimRight(content, "\n"), "\n")
+
+	name := strings.TrimSpace(strings.TrimLeft(heading, "#"))
+	if name == "" {
+		return strings.Join(lines, "\n") + "\n\n" + text + "\n"
+	}
+
+	start, end, ok := sectionRange(lines, name)
+	if !ok {
+		trimmed := strings.TrimSpace(heading)
+		level := len(trimmed) - len(strings.TrimLeft(trimmed, "#"))
+		if level == 0 {
+			level = 2
+		}
+		return strings.Join(lines, "\n") + "\n\n" + strings.Repeat("#", level) + " " + name + "\n\n" + text + "\n"
+	}
+
+	insert := end
+	for insert > start+1 && strings.TrimSpace(lines[insert-1]) == "" {
+		insert--
+	}
+
+	var out []string
+	out = append(out, lines[:insert]...)
+	if insert == start+1 {
+		out = append(out, "") // 見出し直後は空行を挟む
+	}
+	out = append(out, text)
+	if end < len(lines) {
+		out = append(out, "")
+	}
+	out = append(out, lines[end:]...)
+	return strings.Join(out, "\n") + "\n"
+}
+
+// sectionRange returns the heading line of the section named name and the
+// index where the section ends (the next heading of the same or higher level).
+func sectionRange(lines []string, name string) (start, end int, ok bool) {
+	hs := scanHeadings(lines)
+	for i, h := range hs {
+		if !strings.EqualFold(h.name, name) {
+			continue
+		}
+		end = len(lines)
+		for _, next := range hs[i+1:] {
+			if next.level <= h.level {
+				end = next.line
+				break
+			}
+		}
+		return h.line, end, true
+	}
+	return -1, -1, false
+}
+
+type heading struct {
+	line  int
+	level int
+	name  string
+}
+
+// scanHeadings lists ATX headings, skipping the frontmatter and code fences.
+func scanHeadings(lines []string) []heading {
+	var hs []heading
+	inFrontmatter := len(lines) > 0 && lines[0] == "---"
+	inFence := false
+	for i, l := range lines {
+		switch {
+		case inFrontmatter:
+			if i > 0 && l == "---" {
+				inFrontmatter = false
+			}
+		case strings.HasPrefix(strings.TrimSpace(l), "```"):
+			inFence = !inFence
+		case !inFence:
+			if m := headingRe.FindStringSubmatch(l); m != nil {
+				hs = append(hs, heading{line: i, level: len(m[1]), name: m[2]})
+			}
+		}
+	}
+	return hs
+}
+
+// touchModified rewrites the frontmatter's modified field.
+func touchModified(content string, now time.Time) string {
+	if !strings.HasPrefix(content, "---") {
+		return content
+	}
+	lines := strings.Split(content, "\n")
+	for i := 1; i < len(lines); i++ {
+		if lines[i] == "---" {
+			break
+		}
+		if strings.HasPrefix(lines[i], "modified:") {
+			lines[i] = "modified: " + now.Format(time.RFC3339)
+			break
+		}
+	}
+	return strings.Join(lines, "\n")
+}
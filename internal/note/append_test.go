@@ -0,0 +1,139 @@
+package note
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAppendToSection(t *testing.T) {
+	daily := "---\ntitle: d\n---\n\n## やること\n\n- [ ] a\n\n## メモ\n\n## 振り返り\n\n---\n2026-01-10\n"
+
+	tests := []struct {
+		name    string
+		content string
+		heading string
+		text    string
+		want    string
+	}{
+		{
+			name:    "end of note",
+			content: "---\ntitle: x\n---\n\n# x\n\nbody\n\n",
+			text:    "追記\n",
+			want:    "---\ntitle: x\n---\n\n# x\n\nbody\n\n追記\n",
+		},
+		{
+			name:    "empty section",
+			content: daily,
+			heading: "メモ",
+			text:    "- 10:00 電話",
+			want:    "---\ntitle: d\n---\n\n## やること\n\n- [ ] a\n\n## メモ\n\n- 10:00 電話\n\n## 振り返り\n\n---\n2026-01-10\n",
+		},
+		{
+			name:    "after existing items",
+			content: daily,
+			heading: "## やること",
+			text:    "- [ ] b",
+			want:    "---\ntitle: d\n---\n\n## やること\n\n- [ ] a\n- [ ] b\n\n## メモ\n\n## 振り返り\n\n---\n2026-01-10\n",
+		},
+		{
+			name:    "last section",
+			content: "# t\n\n## Log\n\n### sub\n\nold\n",
+			heading: "log",
+			text:    "new",
+			want:    "# t\n\n## Log\n\n### sub\n\nold\nnew\n",
+		},
+		{
+			name:    "missing heading is created",
+			content: "# t\n\nbody\n",
+			heading: "### Inbox",
+			text:    "x",
+			want:    "# t\n\nbody\n\n### Inbox\n\nx\n",
+		},
+		{
+			name:    "ignores headings in code fences",
+			content: "# t\n\n```sh\n# Log\n```\n\n## Log\n",
+			heading: "Log",
+			text:    "x",
+			want:    "# t\n\n```sh\n# Log\n```\n\n## Log\n\nx\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := appendToSection(tt.content, tt.heading, tt.text); got != tt.want {
+				t.Errorf("appendToSection() =\n%q\nwant\n%q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStorageAppend(t *testing.T) {
+	storage, tmpDir := setupTestStorage(t)
+	defer os.RemoveAll(tmpDir)
+
+	n := NewNote("追記テスト", nil)
+	n.Modified = time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	n.Content = "本文"
+	if err := storage.Save(n); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	if err := storage.Append(n.ID, "一行目\n二行目", ""); err != nil {
+		t.Fatalf("Append() error = %v", err)
+	}
+
+	loaded, err := storage.Load(n.ID)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if !strings.HasSuffix(loaded.Content, "本文\n\n一行目\n二行目") {
+		t.Errorf("Content = %q", loaded.Content)
+	}
+	if loaded.Modified.Year() == 2020 {
+		t.Error("Append() should update the modified time")
+	}
+
+	if err := storage.Append("missing.md", "x", ""); err == nil {
+		t.Error("Append() should fail for a missing note")
+	}
+}
+
+func TestSectionItems(t *testing.T) {
+	storage, tmpDir := setupTestStorage(t)
+	defer os.RemoveAll(tmpDir)
+
+	content := "---\ntitle: d\n---\n\n## やること\n\n- [ ] a\n\n## メモ\n\n- 09:00 一つ目\n  - 補足\n- 10:00 二つ目\n-\n\n## 振り返り\n\n- 感想\n"
+	os.WriteFile(storage.GetPath("d.md"), []byte(content), 0644)
+
+	items, err := storage.SectionItems("d.md", "## メモ")
+	if err != nil {
+		t.Fatalf("SectionItems() error = %v", err)
+	}
+	if len(items) != 2 || items[0] != "09:00 一つ目" || items[1] != "10:00 二つ目" {
+		t.Errorf("SectionItems() = %q", items)
+	}
+
+	if items, _ := storage.SectionItems("d.md", "なし"); len(items) != 0 {
+		t.Errorf("SectionItems(missing heading) = %q, want none", items)
+	}
+	if items, _ := storage.SectionItems("d.md", ""); len(items) != 4 {
+		t.Errorf("SectionItems(whole note) = %q, want 4 items", items)
+	}
+
+	if err := storage.RemoveSectionItem("d.md", "メモ", "09:00 一つ目"); err != nil {
+		t.Fatalf("RemoveSectionItem() error = %v", err)
+	}
+	items, _ = storage.SectionItems("d.md", "メモ")
+	if len(items) != 1 || items[0] != "10:00 二つ目" {
+		t.Errorf("after remove = %q", items)
+	}
+	if data, _ := os.ReadFile(storage.GetPath("d.md")); strings.Contains(string(data), "補足") {
+		t.Error("RemoveSectionItem() should remove child lines too")
+	}
+
+	if err := storage.RemoveSectionItem("d.md", "メモ", "感想"); err == nil {
+		t.Error("RemoveSectionItem() should not touch items in other sections")
+	}
+}
@@ -0,0 +1,93 @@
+package note
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/intiramisu/note-cli/internal/config"
+)
+
+// EnsureDaily はデイリーノートがなければテンプレートから作成し、メモIDと作成したかどうかを返す
+func (s *Storage) EnsureDaily(cfg *config.Config, date time.Time) (string, bool, error) {
+	notesDir := cfg.NotesDir
+
+	// daily ディレクトリを確保
+	dailyDir := filepath.Join(notesDir, cfg.Paths.DailyDir)
+	if err := os.MkdirAll(dailyDir, 0755); err != nil {
+		return "", false, fmt.Errorf("dailyディレクトリの作成に失敗: %w", err)
+	}
+
+	dateStr := date.Format(cfg.Formats.Date)
+	id := cfg.GetDailyNoteID(date)
+	filePath := s.GetPath(id)
+
+	// 既存のノートがあればそのまま
+	if _, err := os.Stat(filePath); err == nil {
+		return id, false, nil
+	}
+
+	// 新規作成
+	content, err := loadDailyTemplate(notesDir, date, cfg)
+	if err != nil {
+		return "", false, err
+	}
+
+	n := &Note{
+		ID:       filepath.Join(cfg.Paths.DailyDir, dateStr),
+		Title:    dateStr,
+		Created:  time.Now(),
+		Modified: time.Now(),
+		Tags:     []string{"daily"},
+		Content:  content,
+	}
+
+	if err := s.SaveAt(n, filePath); err != nil {
+		return "", false, err
+	}
+	return id, true, nil
+}
+
+func loadDailyTemplate(notesDir string, date time.Time, cfg *config.Config) (string, error) {
+	templatePath := filepath.Join(notesDir, cfg.Paths.TemplatesDir, "daily.md")
+
+	data, err := os.ReadFile(templatePath)
+	if err != nil {
+		// テンプレートがなければデフォルト
+		return getDefaultDailyContent(date, cfg), nil
+	}
+
+	// テンプレート内の変数を置換
+	content := string(data)
+	content = strings.ReplaceAll(content, "{{date}}", date.Format(cfg.Formats.Date))
+	content = strings.ReplaceAll(content, "{{year}}", date.Format("2006"))
+	content = strings.ReplaceAll(content, "{{month}}", date.Format("01"))
+	content = strings.ReplaceAll(content, "{{day}}", date.Format("02"))
+	content = strings.ReplaceAll(content, "{{weekday}}", date.Weekday().String())
+
+	return content, nil
+}
+
+func getDefaultDailyContent(date time.Time, cfg *config.Config) string {
+	dateStr := date.Format(cfg.Formats.Date)
+	weekday := getJapaneseWeekday(date.Weekday())
+
+	return fmt.Sprintf(`## やること
+
+- [ ]
+
+## メモ
+
+## 振り返り
+
+---
+%s (%s)
+`, dateStr, weekday)
+}
+
+func getJapaneseWeekday(w time.Weekday) string {
+	weekdays := []string{"日", "月", "火", "水", "木", "金", "土"}
+	return weekdays[w]
+}
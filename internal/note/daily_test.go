@@ -0,0 +1,43 @@
+package note
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/intiramisu/note-cli/internal/config"
+)
+
+func TestStorageEnsureDaily(t *testing.T) {
+	storage, tmpDir := setupTestStorage(t)
+	defer os.RemoveAll(tmpDir)
+
+	cfg := &config.Config{
+		NotesDir: tmpDir,
+		Paths:    config.Paths{DailyDir: "daily", TemplatesDir: ".templates"},
+		Formats:  config.Formats{Date: "2006-01-02"},
+	}
+	date := time.Date(2026, 3, 2, 9, 0, 0, 0, time.Local)
+
+	id, created, err := storage.EnsureDaily(cfg, date)
+	if err != nil {
+		t.Fatalf("EnsureDaily() error = %v", err)
+	}
+	if id != "daily/2026-03-02.md" || !created {
+		t.Errorf("EnsureDaily() = %q, %v, want daily/2026-03-02.md, true", id, created)
+	}
+
+	n, err := storage.Load(id)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if n.Title != "2026-03-02" || !n.HasTag("daily") || !strings.Contains(n.Content, "2026-03-02 (月)") {
+		t.Errorf("daily note = %+v, want default daily layout", n)
+	}
+
+	id, created, err = storage.EnsureDaily(cfg, date)
+	if err != nil || created || id != "daily/2026-03-02.md" {
+		t.Errorf("EnsureDaily() on existing note = %q, %v, %v, want not created", id, created, err)
+	}
+}
@@ -1,6 +1,7 @@
 package note
 
 import (
+	"errors"
 	"regexp"
 	"strings"
 )
@@ -22,14 +23,19 @@ func ExtractLinks(content string) []string {
 	return links
 }
 
-// ResolveLinks はリンク名からノートを検索し、見つかったものと見つからなかったものを返す
-func ResolveLinks(storage *Storage, links []string) (found []*Note, notFound []string) {
+// ResolveLinks はリンク名からノートを検索し、見つかったもの・見つからなかったもの・
+// 複数のノートに該当したものを返す
+func ResolveLinks(storage *Storage, links []string) (found []*Note, notFound []string, ambiguous []*AmbiguousError) {
 	for _, link := range links {
-		n, err := storage.Find(link)
-		if err != nil {
-			notFound = append(notFound, link)
-		} else {
+		n, err := PreferExact(storage.Find(link))
+		var amb *AmbiguousError
+		switch {
+		case err == nil:
 			found = append(found, n)
+		case errors.As(err, &amb):
+			ambiguous = append(ambiguous, amb)
+		default:
+			notFound = append(notFound, link)
 		}
 	}
 	return
@@ -82,8 +82,8 @@ func TestResolveLinks(t *testing.T) {
 	noteB.Content = "Content B"
 	storage.Save(noteB)
 
-	links := []string{"メモA", "メモB", "存在しない"}
-	found, notFound := ResolveLinks(storage, links)
+	links := []string{"メモA", "メモB", "存在しない", "メモ"}
+	found, notFound, ambiguous := ResolveLinks(storage, links)
 
 	if len(found) != 2 {
 		t.Errorf("ResolveLinks() found %d notes, want 2", len(found))
@@ -94,6 +94,9 @@ func TestResolveLinks(t *testing.T) {
 	if len(notFound) > 0 && notFound[0] != "存在しない" {
 		t.Errorf("ResolveLinks() notFound[0] = %q, want %q", notFound[0], "存在しない")
 	}
+	if len(ambiguous) != 1 || ambiguous[0].Query != "メモ" || len(ambiguous[0].Candidates) != 2 {
+		t.Errorf("ResolveLinks() ambiguous = %v, want メモ with 2 candidates", ambiguous)
+	}
 }
 
 func TestFindBacklinks(t *testing.T) {
@@ -0,0 +1,94 @@
+package note
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const itemPrefix = "- "
+
+// SectionItems returns the top-level "- " list items under heading,
+// without the bullet. An empty heading means the whole note.
+func (s *Storage) SectionItems(filename, heading string) ([]string, error) {
+	data, err := os.ReadFile(filepath.Join(s.notesDir, filename))
+	if err != nil {
+		return nil, fmt.Errorf("メモの読み込みに失敗: %w", err)
+	}
+
+	lines := strings.Split(string(data), "\n")
+	start, end := sectionBody(lines, heading)
+	var items []string
+	for _, l := range lines[start:end] {
+		if item, ok := listItem(l); ok {
+			items = append(items, item)
+		}
+	}
+	return items, nil
+}
+
+// RemoveSectionItem deletes the first list item equal to item under heading,
+// together with its indented child lines.
+func (s *Storage) RemoveSectionItem(filename, heading, item string) error {
+	path := filepath.Join(s.notesDir, filename)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("メモの読み込みに失敗: %w", err)
+	}
+
+	lines := strings.Split(string(data), "\n")
+	start, end := sectionBody(lines, heading)
+	for i := start; i < end; i++ {
+		if got, ok := listItem(lines[i]); ok && got == item {
+			// インデントされた子要素も一緒に削除
+			next := i + 1
+			for next < end && isChildLine(lines[next]) {
+				next++
+			}
+			lines = append(lines[:i], lines[next:]...)
+			content := touchModified(strings.Join(lines, "\n"), time.Now())
+			if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+				return fmt.Errorf("メモの保存に失敗: %w", err)
+			}
+			return nil
+		}
+	}
+	return fmt.Errorf("項目が見つかりません: %s", item)
+}
+
+// sectionBody returns the line range of the section under heading,
+// or of everything after the frontmatter when heading is empty or missing.
+func sectionBody(lines []string, heading string) (int, int) {
+	name := strings.TrimSpace(strings.TrimLeft(heading, "#"))
+	if name != "" {
+		if start, end, ok := sectionRange(lines, name); ok {
+			return start + 1, end
+		}
+		return len(lines), len(lines)
+	}
+
+	start := 0
+	if len(lines) > 0 && lines[0] == "---" {
+		for i := 1; i < len(lines); i++ {
+			if lines[i] == "---" {
+				start = i + 1
+				break
+			}
+		}
+	}
+	return start, len(lines)
+}
+
+func listItem(line string) (string, bool) {
+	if !strings.HasPrefix(line, itemPrefix) {
+		return "", false
+	}
+	item := strings.TrimSpace(strings.TrimPrefix(line, itemPrefix))
+	return item, item != ""
+}
+
+func isChildLine(line string) bool {
+	return strings.TrimSpace(line) != "" && (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t"))
+}
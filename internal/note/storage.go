@@ -1,6 +1,7 @@
 package note
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -23,6 +24,11 @@ func NewStorage(notesDir string) (*Storage, error) {
 	return &Storage{notesDir: notesDir}, nil
 }
 
+// Dir returns the notes directory.
+func (s *Storage) Dir() string {
+	return s.notesDir
+}
+
 func (s *Storage) Save(note *Note) error {
 	filename := s.generateFilename(note.Title)
 	fullPath := filepath.Join(s.notesDir, filename)
@@ -73,17 +79,8 @@ func (s *Storage) List(tagFilter string) ([]*Note, error) {
 			return nil
 		}
 
-		if tagFilter != "" {
-			hasTag := false
-			for _, tag := range note.Tags {
-				if tag == tagFilter {
-					hasTag = true
-					break
-				}
-			}
-			if !hasTag {
-				return nil
-			}
+		if tagFilter != "" && !note.HasTag(tagFilter) {
+			return nil
 		}
 
 		notes = append(notes, note)
@@ -140,15 +137,73 @@ func (s *Storage) formatNote(note *Note) string {
 	return sb.String()
 }
 
+// ErrNotFound is returned (wrapped) when no note matches a query.
+var ErrNotFound = errors.New("メモが見つかりません")
+
+// AmbiguousError is returned by Find when a query matches several notes.
+type AmbiguousError struct {
+	Query      string
+	Candidates []*Note
+}
+
+func (e *AmbiguousError) Error() string {
+	titles := make([]string, len(e.Candidates))
+	for i, n := range e.Candidates {
+		titles[i] = n.Title
+	}
+	return fmt.Sprintf("複数のメモが該当します: %s (%s)", e.Query, strings.Join(titles, ", "))
+}
+
+// Exact returns the only candidate whose title is exactly the query
+// (case-sensitive), or nil.
+func (e *AmbiguousError) Exact() *Note {
+	var exact *Note
+	for _, n := range e.Candidates {
+		if n.Title == e.Query {
+			if exact != nil {
+				return nil
+			}
+			exact = n
+		}
+	}
+	return exact
+}
+
+// PreferExact resolves an *AmbiguousError returned by Find to its exact
+// candidate when there is one; any other result is returned unchanged.
+func PreferExact(n *Note, err error) (*Note, error) {
+	var ambiguous *AmbiguousError
+	if errors.As(err, &ambiguous) {
+		if exact := ambiguous.Exact(); exact != nil {
+			return exact, nil
+		}
+	}
+	return n, err
+}
+
+// Find returns the note matching query, or an *AmbiguousError when several do.
 func (s *Storage) Find(query string) (*Note, error) {
+	candidates, err := s.FindAll(query)
+	if err != nil {
+		return nil, err
+	}
+	if len(candidates) > 1 {
+		return nil, &AmbiguousError{Query: query, Candidates: candidates}
+	}
+	return candidates[0], nil
+}
+
+// FindAll returns every note matching query: a file path match, else notes
+// whose title equals query, else notes whose title contains it (newest first).
+func (s *Storage) FindAll(query string) ([]*Note, error) {
 	if strings.HasSuffix(query, ".md") {
 		if note, err := s.Load(query); err == nil {
-			return note, nil
+			return []*Note{note}, nil
 		}
 	}
 
 	if note, err := s.Load(query + ".md"); err == nil {
-		return note, nil
+		return []*Note{note}, nil
 	}
 
 	notes, err := s.List("")
@@ -156,19 +211,23 @@ func (s *Storage) Find(query string) (*Note, error) {
 		return nil, err
 	}
 
+	var exact, partial []*Note
 	for _, n := range notes {
-		if strings.EqualFold(n.Title, query) {
-			return n, nil
+		switch {
+		case strings.EqualFold(n.Title, query):
+			exact = append(exact, n)
+		case strings.Contains(strings.ToLower(n.Title), strings.ToLower(query)):
+			partial = append(partial, n)
 		}
 	}
-
-	for _, n := range notes {
-		if strings.Contains(strings.ToLower(n.Title), strings.ToLower(query)) {
-			return n, nil
-		}
+	if len(exact) > 0 {
+		return exact, nil
+	}
+	if len(partial) > 0 {
+		return partial, nil
 	}
 
-	return nil, fmt.Errorf("メモが見つかりません: %s", query)
+	return nil, fmt.Errorf("%w: %s", ErrNotFound, query)
 }
 
 func (s *Storage) Delete(filename string) error {
@@ -179,6 +238,76 @@ func (s *Storage) Delete(filename string) error {
 	return nil
 }
 
+// ErrExists is returned when a note file with the same name already exists.
+var ErrExists = errors.New("同名のメモが既に存在します")
+
+// Create saves a new note, failing with ErrExists instead of overwriting a file.
+func (s *Storage) Create(note *Note) error {
+	filename := s.generateFilename(note.Title)
+	if _, err := os.Stat(filepath.Join(s.notesDir, filename)); err == nil {
+		return fmt.Errorf("%w: %s", ErrExists, filename)
+	}
+	return s.Save(note)
+}
+
+// Rename changes a note's title, rewriting the frontmatter and a leading
+// "# title" heading, and moves the file to the new title's filename in the
+// same directory. The rest of the file is left untouched.
+func (s *Storage) Rename(filename, title string) (*Note, error) {
+	n, err := s.Load(filename)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(filepath.Join(s.notesDir, filename))
+	if err != nil {
+		return nil, fmt.Errorf("メモの読み込みに失敗: %w", err)
+	}
+
+	newID := filepath.Join(filepath.Dir(filename), s.generateFilename(title))
+	if newID != filename {
+		if _, err := os.Stat(filepath.Join(s.notesDir, newID)); err == nil {
+			return nil, fmt.Errorf("%w: %s", ErrExists, newID)
+		}
+	}
+
+	content := retitle(string(data), n.Title, title)
+	content = touchModified(content, time.Now())
+	if err := os.WriteFile(filepath.Join(s.notesDir, newID), []byte(content), 0644); err != nil {
+		return nil, fmt.Errorf("メモの保存に失敗: %w", err)
+	}
+	if newID != filename {
+		if err := os.Remove(filepath.Join(s.notesDir, filename)); err != nil {
+			return nil, fmt.Errorf("元のメモの削除に失敗: %w", err)
+		}
+	}
+	return s.Load(newID)
+}
+
+// retitle rewrites the frontmatter title and the first heading if it repeats the old title.
+func retitle(content, oldTitle, title string) string {
+	lines := strings.Split(content, "\n")
+	inFrontmatter := len(lines) > 0 && lines[0] == "---"
+	for i := 1; i < len(lines); i++ {
+		l := lines[i]
+		if inFrontmatter {
+			if l == "---" {
+				inFrontmatter = false
+			} else if strings.HasPrefix(l, "title:") {
+				lines[i] = "title: " + title
+			}
+			continue
+		}
+		if strings.TrimSpace(l) == "" {
+			continue
+		}
+		if l == "# "+oldTitle {
+			lines[i] = "# " + title
+		}
+		break
+	}
+	return strings.Join(lines, "\n")
+}
+
 func (s *Storage) GetPath(filename string) string {
 	return filepath.Join(s.notesDir, filename)
 }
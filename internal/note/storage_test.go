@@ -1,6 +1,7 @@
 package note
 
 import (
+	"errors"
 	"os"
 	"path/filepath"
 	"strings"
@@ -317,3 +318,120 @@ func TestStorageSaveAt(t *testing.T) {
 		t.Error("Saved file should contain the content")
 	}
 }
+
+func TestStorageFindAll(t *testing.T) {
+	storage, tmpDir := setupTestStorage(t)
+	defer os.RemoveAll(tmpDir)
+
+	for _, title := range []string{"会議メモ", "定例会議", "会議"} {
+		if err := storage.Save(NewNote(title, nil)); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+	}
+	os.MkdirAll(filepath.Join(tmpDir, "sub"), 0755)
+	sub := NewNote("会議", nil)
+	storage.SaveAt(sub, filepath.Join(tmpDir, "sub", "会議.md"))
+
+	tests := []struct {
+		query string
+		want  int
+	}{
+		{"会議.md", 1},  // ファイル名一致
+		{"sub/会議", 1}, // パス一致
+		{"会議メモ", 1},   // タイトル完全一致
+		{"議", 4},      // 部分一致はすべて
+		{"定例", 1},
+	}
+	for _, tt := range tests {
+		got, err := storage.FindAll(tt.query)
+		if err != nil {
+			t.Fatalf("FindAll(%q) error = %v", tt.query, err)
+		}
+		if len(got) != tt.want {
+			t.Errorf("FindAll(%q) returned %d notes, want %d", tt.query, len(got), tt.want)
+		}
+	}
+
+	if _, err := storage.FindAll("なし"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("FindAll(missing) error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestStorageFindAmbiguous(t *testing.T) {
+	storage, tmpDir := setupTestStorage(t)
+	defer os.RemoveAll(tmpDir)
+
+	storage.Save(NewNote("週次MTG", nil))
+	storage.Save(NewNote("月次MTG", nil))
+
+	_, err := storage.Find("MTG")
+	var amb *AmbiguousError
+	if !errors.As(err, &amb) {
+		t.Fatalf("Find() error = %v, want *AmbiguousError", err)
+	}
+	if len(amb.Candidates) != 2 || !strings.Contains(err.Error(), "週次MTG") {
+		t.Errorf("AmbiguousError = %v", err)
+	}
+
+	if n, err := storage.Find("週次"); err != nil || n.Title != "週次MTG" {
+		t.Errorf("Find(unique) = %v, %v", n, err)
+	}
+	if n, err := PreferExact(storage.Find("MTG")); !errors.As(err, &amb) {
+		t.Errorf("PreferExact(partial) = %v, %v, want *AmbiguousError", n, err)
+	}
+
+	storage.Save(NewNote("週次mtg", nil))
+	if n, err := PreferExact(storage.Find("週次MTG")); err != nil || n.Title != "週次MTG" {
+		t.Errorf("PreferExact(exact) = %v, %v", n, err)
+	}
+}
+
+func TestStorageCreate(t *testing.T) {
+	storage, tmpDir := setupTestStorage(t)
+	defer os.RemoveAll(tmpDir)
+
+	if err := storage.Create(NewNote("新規", nil)); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	err := storage.Create(NewNote("新規", nil))
+	if !errors.Is(err, ErrExists) {
+		t.Errorf("Create(existing) error = %v, want ErrExists", err)
+	}
+}
+
+func TestStorageRename(t *testing.T) {
+	storage, tmpDir := setupTestStorage(t)
+	defer os.RemoveAll(tmpDir)
+
+	n := NewNote("旧タイトル", []string{"work"})
+	n.Content = "本文\n\n# 旧タイトル は見出しではない\n"
+	if err := storage.Save(n); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	renamed, err := storage.Rename(n.ID, "新タイトル")
+	if err != nil {
+		t.Fatalf("Rename() error = %v", err)
+	}
+	if renamed.ID != "新タイトル.md" || renamed.Title != "新タイトル" {
+		t.Errorf("Rename() = %q (%q), want 新タイトル.md", renamed.ID, renamed.Title)
+	}
+	if len(renamed.Tags) != 1 || renamed.Tags[0] != "work" {
+		t.Errorf("Tags = %v, want [work]", renamed.Tags)
+	}
+	if !strings.HasPrefix(renamed.Content, "# 新タイトル\n") {
+		t.Errorf("Content should start with the new heading, got %q", renamed.Content)
+	}
+	if !strings.Contains(renamed.Content, "# 旧タイトル は見出しではない") {
+		t.Errorf("Body should be unchanged, got %q", renamed.Content)
+	}
+	if _, err := os.Stat(storage.GetPath(n.ID)); !os.IsNotExist(err) {
+		t.Error("Old file should be removed")
+	}
+
+	other := NewNote("別のメモ", nil)
+	storage.Save(other)
+	if _, err := storage.Rename(other.ID, "新タイトル"); !errors.Is(err, ErrExists) {
+		t.Errorf("Rename() onto an existing note error = %v, want ErrExists", err)
+	}
+}
@@ -0,0 +1,131 @@
+package note
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/intiramisu/note-cli/internal/util"
+)
+
+// TagCount is a tag with the number of notes that carry it.
+type TagCount struct {
+	Name  string `json:"name" yaml:"name"`
+	Count int    `json:"count" yaml:"count"`
+}
+
+// 並び順 (CountTags)
+const (
+	SortTagsByCount = "count"
+	SortTagsByName  = "name"
+)
+
+// HasTag reports whether the note carries tag or a tag nested under it.
+func (n *Note) HasTag(tag string) bool {
+	for _, t := range n.Tags {
+		if util.TagMatches(t, tag) {
+			return true
+		}
+	}
+	return false
+}
+
+// MatchTags reports whether the note carries all of tags (matchAll) or any of them.
+// An empty tag list matches every note.
+func (n *Note) MatchTags(tags []string, matchAll bool) bool {
+	if len(tags) == 0 {
+		return true
+	}
+	for _, tag := range tags {
+		has := n.HasTag(tag)
+		if matchAll && !has {
+			return false
+		}
+		if !matchAll && has {
+			return true
+		}
+	}
+	return matchAll
+}
+
+// CountTags counts the notes per tag, sorted by count (ties by name) or by name.
+// A parent tag counts the notes of its nested tags too, each note once.
+func CountTags(notes []*Note, sortBy string) []TagCount {
+	counts := make(map[string]int)
+	for _, n := range notes {
+		seen := make(map[string]bool)
+		for _, tag := range n.Tags {
+			for _, t := range append(util.TagAncestors(tag), tag) {
+				if !seen[t] {
+					seen[t] = true
+					counts[t]++
+				}
+			}
+		}
+	}
+
+	tags := make([]TagCount, 0, len(counts))
+	for name, count := range counts {
+		tags = append(tags, TagCount{Name: name, Count: count})
+	}
+	sort.Slice(tags, func(i, j int) bool {
+		if sortBy != SortTagsByName && tags[i].Count != tags[j].Count {
+			return tags[i].Count > tags[j].Count
+		}
+		return tags[i].Name < tags[j].Name
+	})
+	return tags
+}
+
+// RenameTag renames old (and tags nested under it) to newTag in every note,
+// rewriting only the frontmatter's tags. It returns the number of notes changed.
+func (s *Storage) RenameTag(old, newTag string) (int, error) {
+	notes, err := s.List("")
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for _, n := range notes {
+		tags, changed := util.RenameTag(n.Tags, old, newTag)
+		if !changed {
+			continue
+		}
+		path := filepath.Join(s.notesDir, n.ID)
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return count, fmt.Errorf("メモの読み込みに失敗: %w", err)
+		}
+		if err := os.WriteFile(path, []byte(setFrontmatterTags(string(data), tags)), 0644); err != nil {
+			return count, fmt.Errorf("メモの保存に失敗: %w", err)
+		}
+		count++
+	}
+	return count, nil
+}
+
+// setFrontmatterTags replaces the frontmatter's tags, written inline or as a
+// block list, with an inline list.
+func setFrontmatterTags(content string, tags []string) string {
+	line := "tags: [" + strings.Join(tags, ", ") + "]"
+	lines := strings.Split(content, "\n")
+	for i := 1; i < len(lines); i++ {
+		switch {
+		case lines[i] == "---":
+			// tags がなければ閉じる前に追加
+			out := append(append(lines[:i:i], line), lines[i:]...)
+			return strings.Join(out, "\n")
+		case strings.HasPrefix(lines[i], "tags:"):
+			end := i + 1
+			// ブロック形式の要素はインデントなし (YAML で有効) でもよい
+			for end < len(lines) && lines[end] != "---" && strings.HasPrefix(strings.TrimSpace(lines[end]), "- ") {
+				end++
+			}
+			out := append(append(lines[:i:i], line), lines[end:]...)
+			return strings.Join(out, "\n")
+		}
+	}
+	return content
+}
@@ -0,0 +1,116 @@
+package note
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestNoteMatchTags(t *testing.T) {
+	n := NewNote("メモ", []string{"work", "go"})
+
+	tests := []struct {
+		tags     []string
+		matchAll bool
+		want     bool
+	}{
+		{nil, true, true},
+		{[]string{"work"}, true, true},
+		{[]string{"work", "go"}, true, true},
+		{[]string{"work", "rust"}, true, false},
+		{[]string{"work", "rust"}, false, true},
+		{[]string{"rust", "python"}, false, false},
+	}
+	for _, tt := range tests {
+		if got := n.MatchTags(tt.tags, tt.matchAll); got != tt.want {
+			t.Errorf("MatchTags(%v, %v) = %v, want %v", tt.tags, tt.matchAll, got, tt.want)
+		}
+	}
+}
+
+func TestCountTags(t *testing.T) {
+	notes := []*Note{
+		NewNote("a", []string{"go", "work"}),
+		NewNote("b", []string{"work"}),
+		NewNote("c", []string{"art", "work", "go"}),
+		NewNote("d", nil),
+	}
+
+	byCount := CountTags(notes, SortTagsByCount)
+	want := []TagCount{{"work", 3}, {"go", 2}, {"art", 1}}
+	if !reflect.DeepEqual(byCount, want) {
+		t.Errorf("CountTags(count) = %v, want %v", byCount, want)
+	}
+
+	byName := CountTags(notes, SortTagsByName)
+	want = []TagCount{{"art", 1}, {"go", 2}, {"work", 3}}
+	if !reflect.DeepEqual(byName, want) {
+		t.Errorf("CountTags(name) = %v, want %v", byName, want)
+	}
+}
+
+func TestNoteHasTagHierarchy(t *testing.T) {
+	n := NewNote("メモ", []string{"work/projectA"})
+	if !n.HasTag("work") {
+		t.Error("HasTag(work) should match work/projectA")
+	}
+	if !n.HasTag("work/projectA") {
+		t.Error("HasTag(work/projectA) should match itself")
+	}
+	if n.HasTag("work/projectB") || n.HasTag("wor") {
+		t.Error("HasTag should not match siblings or prefixes")
+	}
+}
+
+func TestCountTagsHierarchy(t *testing.T) {
+	notes := []*Note{
+		NewNote("a", []string{"work/projectA", "work/projectB"}),
+		NewNote("b", []string{"work"}),
+	}
+	got := CountTags(notes, SortTagsByName)
+	want := []TagCount{{"work", 2}, {"work/projectA", 1}, {"work/projectB", 1}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("CountTags() = %v, want %v", got, want)
+	}
+}
+
+func TestStorageRenameTag(t *testing.T) {
+	storage, tmpDir := setupTestStorage(t)
+	defer os.RemoveAll(tmpDir)
+
+	storage.Save(NewNote("インライン", []string{"Work", "go"}))
+	storage.Save(NewNote("無関係", []string{"go"}))
+	block := "---\ntitle: ブロック\ntags:\n  - Work/projectA\n  - work\n---\n\n- 本文のリスト\n"
+	os.WriteFile(filepath.Join(tmpDir, "ブロック.md"), []byte(block), 0644)
+	unindented := "---\ntitle: インデントなし\ntags:\n- Work\n- go\n---\n\n- 本文のリスト\n"
+	os.WriteFile(filepath.Join(tmpDir, "インデントなし.md"), []byte(unindented), 0644)
+
+	count, err := storage.RenameTag("Work", "work")
+	if err != nil {
+		t.Fatalf("RenameTag() error = %v", err)
+	}
+	if count != 3 {
+		t.Errorf("RenameTag() = %d, want 3", count)
+	}
+
+	n, _ := storage.Load("インライン.md")
+	if !reflect.DeepEqual(n.Tags, []string{"work", "go"}) {
+		t.Errorf("inline tags = %v", n.Tags)
+	}
+	n, _ = storage.Load("ブロック.md")
+	if !reflect.DeepEqual(n.Tags, []string{"work/projectA", "work"}) {
+		t.Errorf("block tags = %v", n.Tags)
+	}
+	if !strings.Contains(n.Content, "- 本文のリスト") {
+		t.Errorf("content should be unchanged, got %q", n.Content)
+	}
+	n, err = storage.Load("インデントなし.md")
+	if err != nil {
+		t.Fatalf("Load(unindented) error = %v", err)
+	}
+	if !reflect.DeepEqual(n.Tags, []string{"work", "go"}) || !strings.Contains(n.Content, "- 本文のリスト") {
+		t.Errorf("unindented tags = %v, content = %q", n.Tags, n.Content)
+	}
+}
@@ -0,0 +1,34 @@
+package note
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// ListTemplates returns the template names (without ".md") in dir.
+func ListTemplates(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, e := range entries {
+		if !e.IsDir() && strings.HasSuffix(e.Name(), ".md") {
+			names = append(names, strings.TrimSuffix(e.Name(), ".md"))
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// LoadTemplate reads the template name from dir and fills in {{title}}.
+func LoadTemplate(dir, name, title string) (string, error) {
+	data, err := os.ReadFile(filepath.Join(dir, name+".md"))
+	if err != nil {
+		return "", fmt.Errorf("テンプレートが見つかりません: %s", name)
+	}
+	return strings.ReplaceAll(string(data), "{{title}}", title), nil
+}
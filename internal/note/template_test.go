@@ -0,0 +1,40 @@
+package note
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestListAndLoadTemplates(t *testing.T) {
+	dir, err := os.MkdirTemp("", "note-cli-test-*")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	os.WriteFile(filepath.Join(dir, "meeting.md"), []byte("# {{title}}\n\n## 議題\n"), 0644)
+	os.WriteFile(filepath.Join(dir, "daily.md"), []byte("{{title}}"), 0644)
+	os.WriteFile(filepath.Join(dir, "readme.txt"), []byte("x"), 0644)
+
+	names, err := ListTemplates(dir)
+	if err != nil {
+		t.Fatalf("ListTemplates() error = %v", err)
+	}
+	if want := []string{"daily", "meeting"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("ListTemplates() = %v, want %v", names, want)
+	}
+
+	content, err := LoadTemplate(dir, "meeting", "定例")
+	if err != nil {
+		t.Fatalf("LoadTemplate() error = %v", err)
+	}
+	if content != "# 定例\n\n## 議題\n" {
+		t.Errorf("LoadTemplate() = %q", content)
+	}
+
+	if _, err := LoadTemplate(dir, "missing", "x"); err == nil {
+		t.Error("LoadTemplate(missing) should return error")
+	}
+}
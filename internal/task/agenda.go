@@ -0,0 +1,103 @@
+package task
+
+import (
+	"time"
+)
+
+// AgendaBucket はアジェンダ表示におけるタスクの区分
+type AgendaBucket int
+
+const (
+	BucketOverdue AgendaBucket = iota
+	BucketToday
+	BucketTomorrow
+	BucketThisWeek
+	BucketLater
+	BucketNoDate
+)
+
+// AgendaBuckets is the display order of agenda buckets.
+var AgendaBuckets = []AgendaBucket{
+	BucketOverdue,
+	BucketToday,
+	BucketTomorrow,
+	BucketThisWeek,
+	BucketLater,
+	BucketNoDate,
+}
+
+func (b AgendaBucket) String() string {
+	switch b {
+	case BucketOverdue:
+		return "期限切れ"
+	case BucketToday:
+		return "今日"
+	case BucketTomorrow:
+		return "明日"
+	case BucketThisWeek:
+		return "今週"
+	case BucketLater:
+		return "それ以降"
+	case BucketNoDate:
+		return "期限なし"
+	default:
+		return ""
+	}
+}
+
+// AgendaGroup is a bucket and the pending tasks that fall into it.
+type AgendaGroup struct {
+	Bucket AgendaBucket
+	Tasks  []*Task
+}
+
+// BucketFor returns the agenda bucket for a task.
+// days is the window (in days from today) treated as "this week".
+func BucketFor(t *Task, days int) AgendaBucket {
+	if !t.HasDueDate() {
+		return BucketNoDate
+	}
+	if t.IsOverdue() {
+		return BucketOverdue
+	}
+
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	due := t.DueDate.In(now.Location())
+	dueDay := time.Date(due.Year(), due.Month(), due.Day(), 0, 0, 0, 0, now.Location())
+
+	switch {
+	case dueDay.Equal(today):
+		return BucketToday
+	case dueDay.Equal(today.AddDate(0, 0, 1)):
+		return BucketTomorrow
+	case t.IsDueSoon(days):
+		return BucketThisWeek
+	default:
+		return BucketLater
+	}
+}
+
+// BuildAgenda groups pending tasks into agenda buckets.
+// Every bucket is returned (in AgendaBuckets order) even when empty,
+// and tasks within a bucket keep the order of the input slice.
+func BuildAgenda(tasks []*Task, days int) []AgendaGroup {
+	groups := make([]AgendaGroup, len(AgendaBuckets))
+	for i, b := range AgendaBuckets {
+		groups[i] = AgendaGroup{Bucket: b, Tasks: []*Task{}}
+	}
+
+	for _, t := range tasks {
+		if t.IsDone() {
+			continue
+		}
+		b := BucketFor(t, days)
+		groups[b].Tasks = append(groups[b].Tasks, t)
+	}
+	return groups
+}
+
+// Agenda returns pending tasks grouped by due date, sorted by due date within each bucket.
+func (m *Manager) Agenda(days int) []AgendaGroup {
+	return BuildAgenda(m.ListByDueDate(false), days)
+}
@@ -0,0 +1,108 @@
+package task
+
+import (
+	"os"
+	"testing"
+	"time"
+)
+
+func endOfDay(offset int) time.Time {
+	now := time.Now()
+	return time.Date(now.Year(), now.Month(), now.Day(), 23, 59, 59, 0, now.Location()).AddDate(0, 0, offset)
+}
+
+func TestBucketFor(t *testing.T) {
+	tests := []struct {
+		name string
+		due  time.Time
+		want AgendaBucket
+	}{
+		{"no due date", time.Time{}, BucketNoDate},
+		{"yesterday", endOfDay(-1), BucketOverdue},
+		{"today", endOfDay(0), BucketToday},
+		{"tomorrow", endOfDay(1), BucketTomorrow},
+		{"in 3 days", endOfDay(3), BucketThisWeek},
+		{"in 30 days", endOfDay(30), BucketLater},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := NewTask(1, "テスト", PriorityMedium)
+			task.DueDate = tt.due
+			if got := BucketFor(task, 7); got != tt.want {
+				t.Errorf("BucketFor() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBucketForWindow(t *testing.T) {
+	task := NewTask(1, "テスト", PriorityMedium)
+	task.DueDate = endOfDay(5)
+
+	if got := BucketFor(task, 7); got != BucketThisWeek {
+		t.Errorf("BucketFor(days=7) = %v, want %v", got, BucketThisWeek)
+	}
+	if got := BucketFor(task, 3); got != BucketLater {
+		t.Errorf("BucketFor(days=3) = %v, want %v", got, BucketLater)
+	}
+}
+
+func TestBuildAgenda(t *testing.T) {
+	overdue := NewTask(1, "期限切れ", PriorityHigh)
+	overdue.DueDate = endOfDay(-2)
+	today := NewTask(2, "今日", PriorityMedium)
+	today.DueDate = endOfDay(0)
+	noDate := NewTask(3, "期限なし", PriorityLow)
+	done := NewTask(4, "完了", PriorityHigh)
+	done.DueDate = endOfDay(0)
+	done.Done()
+
+	groups := BuildAgenda([]*Task{overdue, today, noDate, done}, 7)
+
+	if len(groups) != len(AgendaBuckets) {
+		t.Fatalf("BuildAgenda() returned %d groups, want %d", len(groups), len(AgendaBuckets))
+	}
+
+	for i, g := range groups {
+		if g.Bucket != AgendaBuckets[i] {
+			t.Errorf("groups[%d].Bucket = %v, want %v", i, g.Bucket, AgendaBuckets[i])
+		}
+	}
+
+	if len(groups[BucketOverdue].Tasks) != 1 || groups[BucketOverdue].Tasks[0].ID != 1 {
+		t.Errorf("Overdue group = %v, want task 1", groups[BucketOverdue].Tasks)
+	}
+	if len(groups[BucketToday].Tasks) != 1 || groups[BucketToday].Tasks[0].ID != 2 {
+		t.Errorf("Today group = %v, want task 2 only (done excluded)", groups[BucketToday].Tasks)
+	}
+	if len(groups[BucketNoDate].Tasks) != 1 || groups[BucketNoDate].Tasks[0].ID != 3 {
+		t.Errorf("NoDate group = %v, want task 3", groups[BucketNoDate].Tasks)
+	}
+	if len(groups[BucketTomorrow].Tasks) != 0 {
+		t.Errorf("Tomorrow group should be empty, got %d tasks", len(groups[BucketTomorrow].Tasks))
+	}
+}
+
+func TestManagerAgenda(t *testing.T) {
+	manager, tmpDir := setupTestManager(t)
+	defer os.RemoveAll(tmpDir)
+
+	manager.Add("明後日", PriorityLow, "", endOfDay(2))
+	manager.Add("明日", PriorityLow, "", endOfDay(1))
+	manager.Add("来週", PriorityHigh, "", endOfDay(6))
+
+	groups := manager.Agenda(7)
+
+	if len(groups[BucketTomorrow].Tasks) != 1 {
+		t.Errorf("Tomorrow group has %d tasks, want 1", len(groups[BucketTomorrow].Tasks))
+	}
+
+	week := groups[BucketThisWeek].Tasks
+	if len(week) != 2 {
+		t.Fatalf("ThisWeek group has %d tasks, want 2", len(week))
+	}
+	if week[0].Description != "明後日" {
+		t.Errorf("ThisWeek should be sorted by due date, first = %q", week[0].Description)
+	}
+}
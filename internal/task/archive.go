@@ -0,0 +1,151 @@
+package task
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// archiveFilePrefix is the file name prefix of monthly archive files (tasks-2006-01.yaml).
+const archiveFilePrefix = "tasks-"
+
+type archiveFile struct {
+	Tasks []*Task `yaml:"tasks"`
+}
+
+// Archive moves done tasks completed more than olderThan ago out of the
+// active tasks file into monthly archive files (by completion month).
+// It returns the number of archived tasks.
+func (m *Manager) Archive(olderThan time.Duration) (int, error) {
+	now := time.Now()
+	cutoff := now.Add(-olderThan)
+
+	var keep []*Task
+	byMonth := make(map[string][]*Task)
+	count := 0
+	for _, t := range m.tasks {
+		if !t.IsDone() {
+			keep = append(keep, t)
+			continue
+		}
+		// 完了日時のない完了タスクは作成日時 (それもなければ現在) で扱う
+		completed := t.Completed
+		if completed.IsZero() {
+			completed = t.Created
+		}
+		if completed.IsZero() {
+			completed = now
+		}
+		if !completed.After(cutoff) {
+			t.Completed = completed
+			month := t.Completed.Format("2006-01")
+			byMonth[month] = append(byMonth[month], t)
+			count++
+		} else {
+			keep = append(keep, t)
+		}
+	}
+
+	if count == 0 {
+		return 0, nil
+	}
+
+	if err := os.MkdirAll(m.archiveDir, 0755); err != nil {
+		return 0, fmt.Errorf("アーカイブディレクトリの作成に失敗: %w", err)
+	}
+
+	// アーカイブを先に書き込み、失敗時にタスクが失われないようにする
+	// (保存に失敗して再実行されても appendArchive が同じタスクを重複させない)
+	for month, tasks := range byMonth {
+		if err := m.appendArchive(month, tasks); err != nil {
+			return 0, err
+		}
+	}
+
+	if keep == nil {
+		keep = []*Task{}
+	}
+	all := m.tasks
+	m.tasks = keep
+	if err := m.save(); err != nil {
+		m.tasks = all
+		return 0, err
+	}
+	return count, nil
+}
+
+// ListArchived returns all archived tasks, most recently completed first.
+func (m *Manager) ListArchived() ([]*Task, error) {
+	entries, err := os.ReadDir(m.archiveDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("アーカイブの読み込みに失敗: %w", err)
+	}
+
+	var tasks []*Task
+	for _, e := range entries {
+		if e.IsDir() || !isArchiveFile(e.Name()) {
+			continue
+		}
+		stored, err := m.readArchive(filepath.Join(m.archiveDir, e.Name()))
+		if err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, stored.Tasks...)
+	}
+
+	sort.Slice(tasks, func(i, j int) bool {
+		return tasks[i].Completed.After(tasks[j].Completed)
+	})
+	return tasks, nil
+}
+
+func (m *Manager) appendArchive(month string, tasks []*Task) error {
+	path := filepath.Join(m.archiveDir, archiveFilePrefix+month+".yaml")
+
+	stored, err := m.readArchive(path)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	archived := make(map[int]bool, len(stored.Tasks))
+	for _, t := range stored.Tasks {
+		archived[t.ID] = true
+	}
+	for _, t := range tasks {
+		if !archived[t.ID] {
+			stored.Tasks = append(stored.Tasks, t)
+		}
+	}
+
+	data, err := yaml.Marshal(stored)
+	if err != nil {
+		return fmt.Errorf("アーカイブのシリアライズに失敗: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("アーカイブの保存に失敗: %w", err)
+	}
+	return nil
+}
+
+func (m *Manager) readArchive(path string) (archiveFile, error) {
+	var stored archiveFile
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return stored, err
+	}
+	if err := yaml.Unmarshal(data, &stored); err != nil {
+		return stored, fmt.Errorf("アーカイブのパースに失敗 (%s): %w", filepath.Base(path), err)
+	}
+	return stored, nil
+}
+
+func isArchiveFile(name string) bool {
+	return strings.HasPrefix(name, archiveFilePrefix) && strings.HasSuffix(name, ".yaml")
+}
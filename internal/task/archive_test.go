@@ -0,0 +1,162 @@
+package task
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestManagerArchive(t *testing.T) {
+	manager, tmpDir := setupTestManager(t)
+	defer os.RemoveAll(tmpDir)
+
+	manager.Add("古い完了", PriorityHigh, "", time.Time{})
+	manager.Add("新しい完了", PriorityHigh, "", time.Time{})
+	manager.Add("未完了", PriorityHigh, "", time.Time{})
+	manager.Done(1)
+	manager.Done(2)
+
+	old, _ := manager.Get(1)
+	old.Completed = time.Now().AddDate(0, 0, -30)
+
+	count, err := manager.Archive(14 * 24 * time.Hour)
+	if err != nil {
+		t.Fatalf("Archive() error = %v", err)
+	}
+	if count != 1 {
+		t.Errorf("Archive() = %d, want 1", count)
+	}
+
+	if _, err := manager.Get(1); err == nil {
+		t.Error("Archived task should be removed from active tasks")
+	}
+	if len(manager.List(true)) != 2 {
+		t.Errorf("List(true) returned %d tasks, want 2", len(manager.List(true)))
+	}
+
+	archiveFile := filepath.Join(tmpDir, ".archive", "tasks-"+old.Completed.Format("2006-01")+".yaml")
+	if _, err := os.Stat(archiveFile); err != nil {
+		t.Errorf("Archive file %s should exist: %v", archiveFile, err)
+	}
+
+	archived, err := manager.ListArchived()
+	if err != nil {
+		t.Fatalf("ListArchived() error = %v", err)
+	}
+	if len(archived) != 1 || archived[0].Description != "古い完了" {
+		t.Errorf("ListArchived() = %v, want [古い完了]", archived)
+	}
+
+	// New IDs must not reuse archived IDs
+	task := manager.Add("追加", PriorityLow, "", time.Time{})
+	if task.ID != 4 {
+		t.Errorf("New task ID = %d, want 4", task.ID)
+	}
+}
+
+func TestManagerArchiveAppends(t *testing.T) {
+	manager, tmpDir := setupTestManager(t)
+	defer os.RemoveAll(tmpDir)
+
+	manager.Add("タスク1", PriorityHigh, "", time.Time{})
+	manager.Done(1)
+	manager.Archive(0)
+
+	manager.Add("タスク2", PriorityHigh, "", time.Time{})
+	manager.Done(2)
+	manager.Archive(0)
+
+	reloaded, err := NewManager(tmpDir)
+	if err != nil {
+		t.Fatalf("NewManager() error = %v", err)
+	}
+
+	archived, err := reloaded.ListArchived()
+	if err != nil {
+		t.Fatalf("ListArchived() error = %v", err)
+	}
+	if len(archived) != 2 {
+		t.Errorf("ListArchived() returned %d tasks, want 2", len(archived))
+	}
+	if len(reloaded.List(true)) != 0 {
+		t.Errorf("Active tasks = %d, want 0", len(reloaded.List(true)))
+	}
+}
+
+func TestManagerArchiveNothing(t *testing.T) {
+	manager, tmpDir := setupTestManager(t)
+	defer os.RemoveAll(tmpDir)
+
+	manager.Add("未完了", PriorityHigh, "", time.Time{})
+
+	count, err := manager.Archive(0)
+	if err != nil || count != 0 {
+		t.Errorf("Archive() = %d, %v, want 0, nil", count, err)
+	}
+
+	archived, err := manager.ListArchived()
+	if err != nil || len(archived) != 0 {
+		t.Errorf("ListArchived() = %v, %v, want empty", archived, err)
+	}
+}
+
+func TestManagerStatsIncludesArchive(t *testing.T) {
+	manager, tmpDir := setupTestManager(t)
+	defer os.RemoveAll(tmpDir)
+
+	manager.Add("タスク1", PriorityHigh, "", time.Time{})
+	manager.Add("タスク2", PriorityHigh, "", time.Time{})
+	manager.Done(1)
+	manager.Archive(0)
+
+	stats, err := manager.Stats(7)
+	if err != nil {
+		t.Fatalf("Stats() error = %v", err)
+	}
+	if stats.Total != 2 || stats.Completed != 1 {
+		t.Errorf("Total/Completed = %d/%d, want 2/1", stats.Total, stats.Completed)
+	}
+}
+
+func TestManagerArchiveZeroCompleted(t *testing.T) {
+	manager, tmpDir := setupTestManager(t)
+	defer os.RemoveAll(tmpDir)
+
+	task := manager.Add("完了日時なし", PriorityHigh, "", time.Time{})
+	task.Status = StatusDone
+	task.Created = time.Date(2026, 2, 10, 9, 0, 0, 0, time.Local)
+
+	if _, err := manager.Archive(0); err != nil {
+		t.Fatalf("Archive() error = %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(tmpDir, ".archive", "tasks-2026-02.yaml")); err != nil {
+		t.Errorf("Task should be archived by its creation month: %v", err)
+	}
+}
+
+func TestManagerArchiveNoDuplicates(t *testing.T) {
+	manager, tmpDir := setupTestManager(t)
+	defer os.RemoveAll(tmpDir)
+
+	manager.Add("タスク1", PriorityHigh, "", time.Time{})
+	manager.Done(1)
+	task, _ := manager.Get(1)
+
+	// 前回の Archive がアーカイブ書き込み後の保存に失敗した状態
+	os.MkdirAll(filepath.Join(tmpDir, ".archive"), 0755)
+	if err := manager.appendArchive(task.Completed.Format("2006-01"), []*Task{task}); err != nil {
+		t.Fatalf("appendArchive() error = %v", err)
+	}
+	if _, err := manager.Archive(0); err != nil {
+		t.Fatalf("Archive() error = %v", err)
+	}
+
+	archived, err := manager.ListArchived()
+	if err != nil {
+		t.Fatalf("ListArchived() error = %v", err)
+	}
+	if len(archived) != 1 {
+		t.Errorf("ListArchived() returned %d tasks, want 1", len(archived))
+	}
+}
@@ -0,0 +1,246 @@
+package task
+
+import (
+	"sort"
+	"time"
+
+	"github.com/intiramisu/note-cli/internal/config"
+	"github.com/intiramisu/note-cli/internal/util"
+)
+
+// GroupBy values for the kanban board.
+const (
+	GroupByPriority = "priority"
+	GroupByStatus   = "status"
+	GroupByProject  = "project"
+	GroupByTag      = "tag"
+)
+
+// Column values with a special meaning.
+const (
+	ColumnDone    = "done"
+	ColumnPending = "pending"
+)
+
+// Column is a single kanban column.
+type Column struct {
+	Name  string
+	Value string
+	Color string
+}
+
+// Board decides which column a task belongs to and how moving a task
+// between columns changes it.
+type Board struct {
+	GroupBy string
+	Columns []Column
+}
+
+// NewBoard builds a board from config. When no columns are configured,
+// default columns are derived from the theme (priority/status) or from
+// the projects/tags used by tasks (project/tag).
+func NewBoard(cfg config.Board, theme config.Theme, tasks []*Task) *Board {
+	b := &Board{GroupBy: cfg.GroupBy}
+	switch b.GroupBy {
+	case GroupByStatus, GroupByProject, GroupByTag:
+	default:
+		b.GroupBy = GroupByPriority
+	}
+
+	for _, c := range cfg.Columns {
+		color := c.Color
+		if color == "" {
+			color = theme.Colors.Selected
+		}
+		b.Columns = append(b.Columns, Column{Name: c.Name, Value: c.Value, Color: color})
+	}
+	if len(b.Columns) == 0 {
+		b.Columns = defaultColumns(b.GroupBy, theme, tasks)
+	}
+	return b
+}
+
+func defaultColumns(groupBy string, theme config.Theme, tasks []*Task) []Column {
+	colors := theme.Colors
+	sections := theme.Sections
+
+	switch groupBy {
+	case GroupByStatus:
+		return []Column{
+			{Name: "未完了", Value: ColumnPending, Color: colors.Selected},
+			{Name: sections.Done, Value: ColumnDone, Color: colors.Done},
+		}
+
+	case GroupByProject, GroupByTag:
+		seen := make(map[string]bool)
+		var values []string
+		for _, t := range tasks {
+			candidates := t.Tags
+			if groupBy == GroupByProject {
+				candidates = []string{t.Project}
+			}
+			for _, v := range candidates {
+				if v != "" && !seen[v] {
+					seen[v] = true
+					values = append(values, v)
+				}
+			}
+		}
+		sort.Strings(values)
+
+		var columns []Column
+		for _, v := range values {
+			columns = append(columns, Column{Name: v, Value: v, Color: colors.Selected})
+		}
+		return append(columns, Column{Name: "(なし)", Value: "", Color: colors.Empty})
+
+	default:
+		return []Column{
+			{Name: sections.P1, Value: "1", Color: colors.PriorityHigh},
+			{Name: sections.P2, Value: "2", Color: colors.PriorityMedium},
+			{Name: sections.P3, Value: "3", Color: colors.PriorityLow},
+			{Name: sections.Done, Value: ColumnDone, Color: colors.Done},
+		}
+	}
+}
+
+// ColumnFor returns the index of the column the task belongs to, or -1.
+func (b *Board) ColumnFor(t *Task) int {
+	if t.IsDone() && (b.GroupBy == GroupByPriority || b.GroupBy == GroupByStatus) {
+		return b.indexOf(ColumnDone)
+	}
+
+	switch b.GroupBy {
+	case GroupByStatus:
+		return b.indexOf(ColumnPending)
+
+	case GroupByProject:
+		if i := b.indexOf(t.Project); i >= 0 {
+			return i
+		}
+		return b.indexOf("")
+
+	case GroupByTag:
+		// 親タグと子タグの列が両方あれば、より深い (長い) 方に入れる
+		best := -1
+		for i, c := range b.Columns {
+			if c.Value != "" && t.HasTag(c.Value) && (best < 0 || len(c.Value) > len(b.Columns[best].Value)) {
+				best = i
+			}
+		}
+		if best >= 0 {
+			return best
+		}
+		return b.indexOf("")
+
+	default:
+		p := t.Priority
+		if p == PriorityNone {
+			p = PriorityLow
+		}
+		for i, c := range b.Columns {
+			if c.Value != ColumnDone && ParsePriority(c.Value) == p {
+				return i
+			}
+		}
+		return -1
+	}
+}
+
+// Apply changes the task so that it belongs to the column at index col.
+func (b *Board) Apply(t *Task, col int) {
+	if col < 0 || col >= len(b.Columns) {
+		return
+	}
+	value := b.Columns[col].Value
+
+	switch b.GroupBy {
+	case GroupByStatus:
+		setDone(t, value == ColumnDone)
+
+	case GroupByProject:
+		t.Project = value
+
+	case GroupByTag:
+		var tags []string
+		for _, tg := range t.Tags {
+			if !b.matchesTagColumn(tg) {
+				tags = append(tags, tg)
+			}
+		}
+		if value != "" {
+			tags = append(tags, value)
+		}
+		t.Tags = tags
+
+	default:
+		if value == ColumnDone {
+			setDone(t, true)
+			return
+		}
+		setDone(t, false)
+		t.Priority = ParsePriority(value)
+	}
+}
+
+// matchesTagColumn reports whether tag puts a task in one of the tag columns,
+// including tags nested under a column's tag.
+func (b *Board) matchesTagColumn(tag string) bool {
+	for _, c := range b.Columns {
+		if c.Value != "" && util.TagMatches(tag, c.Value) {
+			return true
+		}
+	}
+	return false
+}
+
+func (b *Board) indexOf(value string) int {
+	for i, c := range b.Columns {
+		if c.Value == value {
+			return i
+		}
+	}
+	return -1
+}
+
+func setDone(t *Task, done bool) {
+	if done == t.IsDone() {
+		return
+	}
+	if done {
+		t.Done()
+	} else {
+		t.Status = StatusPending
+		t.Completed = time.Time{}
+	}
+}
+
+// Group distributes tasks into the board's columns, ordered by manual order.
+// Tasks that match no column are left out.
+func (b *Board) Group(tasks []*Task) [][]*Task {
+	grouped := make([][]*Task, len(b.Columns))
+	for i := range grouped {
+		grouped[i] = []*Task{}
+	}
+	for _, t := range tasks {
+		if i := b.ColumnFor(t); i >= 0 {
+			grouped[i] = append(grouped[i], t)
+		}
+	}
+	for i := range grouped {
+		sortByOrder(grouped[i])
+	}
+	return grouped
+}
+
+// MoveToColumn moves a task into the given board column and saves it.
+// The task's manual order is reset so it is placed after ordered tasks.
+func (m *Manager) MoveToColumn(id int, board *Board, col int) error {
+	task, err := m.Get(id)
+	if err != nil {
+		return err
+	}
+	board.Apply(task, col)
+	task.Order = 0
+	return m.save()
+}
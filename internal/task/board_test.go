@@ -0,0 +1,203 @@
+package task
+
+import (
+	"os"
+	"testing"
+
+	"github.com/intiramisu/note-cli/internal/config"
+)
+
+func testTheme() config.Theme {
+	return config.Theme{
+		Sections: config.Sections{P1: "P1", P2: "P2", P3: "P3", Done: "Done"},
+	}
+}
+
+func TestNewBoardDefaults(t *testing.T) {
+	tests := []struct {
+		groupBy string
+		tasks   []*Task
+		want    []string
+	}{
+		{"", nil, []string{"1", "2", "3", ColumnDone}},
+		{"unknown", nil, []string{"1", "2", "3", ColumnDone}},
+		{GroupByStatus, nil, []string{ColumnPending, ColumnDone}},
+		{GroupByProject, []*Task{{Project: "b"}, {Project: "a"}, {Project: "b"}}, []string{"a", "b", ""}},
+		{GroupByTag, []*Task{{Tags: []string{"x", "y"}}, {Tags: []string{"x"}}}, []string{"x", "y", ""}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.groupBy, func(t *testing.T) {
+			b := NewBoard(config.Board{GroupBy: tt.groupBy}, testTheme(), tt.tasks)
+			if len(b.Columns) != len(tt.want) {
+				t.Fatalf("len(Columns) = %d, want %d", len(b.Columns), len(tt.want))
+			}
+			for i, c := range b.Columns {
+				if c.Value != tt.want[i] {
+					t.Errorf("Columns[%d].Value = %q, want %q", i, c.Value, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestNewBoardConfiguredColumns(t *testing.T) {
+	cfg := config.Board{
+		GroupBy: GroupByProject,
+		Columns: []config.BoardColumn{
+			{Name: "仕事", Value: "work", Color: "#ff0000"},
+			{Name: "その他", Value: ""},
+		},
+	}
+	theme := testTheme()
+	theme.Colors.Selected = "#00ff00"
+
+	b := NewBoard(cfg, theme, nil)
+
+	if len(b.Columns) != 2 {
+		t.Fatalf("len(Columns) = %d, want 2", len(b.Columns))
+	}
+	if b.Columns[0].Color != "#ff0000" {
+		t.Errorf("Columns[0].Color = %q, want %q", b.Columns[0].Color, "#ff0000")
+	}
+	if b.Columns[1].Color != "#00ff00" {
+		t.Errorf("Columns[1].Color should default to selected color, got %q", b.Columns[1].Color)
+	}
+}
+
+func TestBoardColumnForPriority(t *testing.T) {
+	b := NewBoard(config.Board{}, testTheme(), nil)
+
+	high := NewTask(1, "high", PriorityHigh)
+	none := NewTask(2, "none", PriorityNone)
+	done := NewTask(3, "done", PriorityHigh)
+	done.Done()
+
+	if got := b.ColumnFor(high); got != 0 {
+		t.Errorf("ColumnFor(high) = %d, want 0", got)
+	}
+	if got := b.ColumnFor(none); got != 2 {
+		t.Errorf("ColumnFor(none) = %d, want 2 (P3)", got)
+	}
+	if got := b.ColumnFor(done); got != 3 {
+		t.Errorf("ColumnFor(done) = %d, want 3", got)
+	}
+}
+
+func TestBoardApplyPriority(t *testing.T) {
+	b := NewBoard(config.Board{}, testTheme(), nil)
+	task := NewTask(1, "テスト", PriorityHigh)
+
+	b.Apply(task, 1)
+	if task.Priority != PriorityMedium {
+		t.Errorf("Priority = %v, want %v", task.Priority, PriorityMedium)
+	}
+
+	b.Apply(task, 3)
+	if !task.IsDone() {
+		t.Error("Task should be done after moving to done column")
+	}
+
+	b.Apply(task, 0)
+	if task.IsDone() {
+		t.Error("Task should be reopened after moving out of done column")
+	}
+	if !task.Completed.IsZero() {
+		t.Error("Completed should be cleared when reopened")
+	}
+	if task.Priority != PriorityHigh {
+		t.Errorf("Priority = %v, want %v", task.Priority, PriorityHigh)
+	}
+}
+
+func TestBoardApplyTag(t *testing.T) {
+	b := NewBoard(config.Board{GroupBy: GroupByTag}, testTheme(), []*Task{
+		{Tags: []string{"todo"}},
+		{Tags: []string{"doing"}},
+	})
+	task := &Task{Tags: []string{"todo", "urgent"}}
+
+	if got := b.ColumnFor(task); b.Columns[got].Value != "todo" {
+		t.Fatalf("ColumnFor() = %q, want todo", b.Columns[got].Value)
+	}
+
+	b.Apply(task, b.indexOf("doing"))
+	if task.HasTag("todo") || !task.HasTag("doing") || !task.HasTag("urgent") {
+		t.Errorf("Tags = %v, want [urgent doing]", task.Tags)
+	}
+
+	b.Apply(task, b.indexOf(""))
+	if len(task.Tags) != 1 || task.Tags[0] != "urgent" {
+		t.Errorf("Tags = %v, want [urgent]", task.Tags)
+	}
+}
+
+func TestBoardNestedTags(t *testing.T) {
+	b := NewBoard(config.Board{GroupBy: GroupByTag}, testTheme(), []*Task{
+		{Tags: []string{"work"}},
+		{Tags: []string{"work/projectA"}},
+	})
+	nested := b.indexOf("work/projectA")
+	task := &Task{Tags: []string{"work/projectA"}}
+
+	if got := b.ColumnFor(task); got != nested {
+		t.Fatalf("ColumnFor() = %q, want work/projectA", b.Columns[got].Value)
+	}
+	if got := b.ColumnFor(&Task{Tags: []string{"work/other"}}); b.Columns[got].Value != "work" {
+		t.Errorf("ColumnFor(work/other) = %q, want work", b.Columns[got].Value)
+	}
+
+	b.Apply(task, b.indexOf("work"))
+	if len(task.Tags) != 1 || task.Tags[0] != "work" {
+		t.Errorf("Tags = %v, want [work]", task.Tags)
+	}
+	b.Apply(task, nested)
+	if b.ColumnFor(task) != nested || len(task.Tags) != 1 {
+		t.Errorf("Tags = %v, want [work/projectA]", task.Tags)
+	}
+	b.Apply(task, b.indexOf(""))
+	if len(task.Tags) != 0 {
+		t.Errorf("Tags = %v, want []", task.Tags)
+	}
+}
+
+func TestBoardGroupOrder(t *testing.T) {
+	b := NewBoard(config.Board{}, testTheme(), nil)
+	t1 := NewTask(1, "a", PriorityHigh)
+	t2 := NewTask(2, "b", PriorityHigh)
+	t3 := NewTask(3, "c", PriorityHigh)
+	t2.Order = 1
+	t3.Order = 2
+
+	grouped := b.Group([]*Task{t1, t2, t3})
+	col := grouped[0]
+	if len(col) != 3 {
+		t.Fatalf("len(grouped[0]) = %d, want 3", len(col))
+	}
+	if col[0].ID != 2 || col[1].ID != 3 || col[2].ID != 1 {
+		t.Errorf("order = [%d %d %d], want [2 3 1]", col[0].ID, col[1].ID, col[2].ID)
+	}
+}
+
+func TestManagerMoveToColumn(t *testing.T) {
+	manager, tmpDir := setupTestManager(t)
+	defer os.RemoveAll(tmpDir)
+
+	b := NewBoard(config.Board{GroupBy: GroupByProject, Columns: []config.BoardColumn{{Value: "a"}, {Value: "b"}}}, testTheme(), nil)
+	task := manager.Add("タスク", PriorityMedium, "", endOfDay(0))
+	task.Order = 5
+
+	if err := manager.MoveToColumn(task.ID, b, 1); err != nil {
+		t.Fatalf("MoveToColumn() error = %v", err)
+	}
+	if task.Project != "b" {
+		t.Errorf("Project = %q, want %q", task.Project, "b")
+	}
+	if task.Order != 0 {
+		t.Errorf("Order = %d, want 0 after move", task.Order)
+	}
+
+	if err := manager.MoveToColumn(999, b, 0); err == nil {
+		t.Error("MoveToColumn() should fail for unknown ID")
+	}
+}
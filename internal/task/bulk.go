@@ -0,0 +1,284 @@
+package task
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// IDSelection is a set of task IDs given as arguments. Single IDs must
+// exist, while ranges select the existing tasks within them.
+type IDSelection struct {
+	IDs    []int
+	Ranges [][2]int
+}
+
+// ParseIDs parses IDs given as numbers or ranges such as "7-10"; several
+// can also be joined with commas ("3,5").
+func ParseIDs(args []string) (IDSelection, error) {
+	var sel IDSelection
+	for _, arg := range args {
+		for _, part := range strings.Split(arg, ",") {
+			if part == "" {
+				continue
+			}
+			from, to, isRange := strings.Cut(part, "-")
+			start, err := strconv.Atoi(from)
+			if err != nil || start <= 0 {
+				return IDSelection{}, fmt.Errorf("無効なID: %s", part)
+			}
+			if !isRange {
+				sel.IDs = append(sel.IDs, start)
+				continue
+			}
+			end, err := strconv.Atoi(to)
+			if err != nil || end < start {
+				return IDSelection{}, fmt.Errorf("無効な範囲: %s", part)
+			}
+			sel.Ranges = append(sel.Ranges, [2]int{start, end})
+		}
+	}
+	return sel, nil
+}
+
+// IsEmpty reports whether no IDs were given.
+func (s IDSelection) IsEmpty() bool {
+	return len(s.IDs) == 0 && len(s.Ranges) == 0
+}
+
+func (s IDSelection) Contains(id int) bool {
+	for _, x := range s.IDs {
+		if x == id {
+			return true
+		}
+	}
+	for _, r := range s.Ranges {
+		if id >= r[0] && id <= r[1] {
+			return true
+		}
+	}
+	return false
+}
+
+// Filter narrows tasks by their attributes. Zero fields match every task.
+type Filter struct {
+	Overdue  bool
+	Tags     []string // すべてのタグを持つ (親タグは子タグにも一致)
+	Project  string
+	Priority Priority // PriorityNone は条件なし
+	NoteID   string
+	Query    *Query // nil は条件なし
+}
+
+// IsZero reports whether the filter has no conditions.
+func (f Filter) IsZero() bool {
+	return !f.Overdue && len(f.Tags) == 0 && f.Project == "" && f.Priority == PriorityNone && f.NoteID == "" && f.Query == nil
+}
+
+func (f Filter) Match(t *Task) bool {
+	if f.Overdue && !t.IsOverdue() {
+		return false
+	}
+	for _, tag := range f.Tags {
+		if !t.HasTag(tag) {
+			return false
+		}
+	}
+	if f.Project != "" && t.Project != f.Project {
+		return false
+	}
+	if f.Priority != PriorityNone && t.Priority != f.Priority {
+		return false
+	}
+	if f.NoteID != "" && t.NoteID != f.NoteID {
+		return false
+	}
+	if f.Query != nil && !f.Query.Match(t) {
+		return false
+	}
+	return true
+}
+
+// Select returns the tasks in sel (every task when sel is empty) that match
+// f, in ID order. Single IDs that do not exist are an error.
+func (m *Manager) Select(sel IDSelection, f Filter) ([]*Task, error) {
+	for _, id := range sel.IDs {
+		if _, err := m.Get(id); err != nil {
+			return nil, err
+		}
+	}
+
+	var tasks []*Task
+	for _, t := range m.tasks {
+		if (sel.IsEmpty() || sel.Contains(t.ID)) && f.Match(t) {
+			tasks = append(tasks, t)
+		}
+	}
+	sort.Slice(tasks, func(i, j int) bool {
+		return tasks[i].ID < tasks[j].ID
+	})
+	return tasks, nil
+}
+
+// UpdateAll applies fn to the tasks with ids and saves once. Nothing is
+// changed when one of the IDs does not exist.
+func (m *Manager) UpdateAll(ids []int, fn func(t *Task)) error {
+	tasks := make([]*Task, len(ids))
+	for i, id := range ids {
+		t, err := m.Get(id)
+		if err != nil {
+			return err
+		}
+		tasks[i] = t
+	}
+	for _, t := range tasks {
+		fn(t)
+	}
+	return m.save()
+}
+
+// DeleteAll removes the tasks with ids and saves once. Nothing is deleted
+// when one of the IDs does not exist.
+func (m *Manager) DeleteAll(ids []int) error {
+	remove := make(map[int]bool, len(ids))
+	for _, id := range ids {
+		if _, err := m.Get(id); err != nil {
+			return err
+		}
+		remove[id] = true
+	}
+
+	kept := m.tasks[:0]
+	for _, t := range m.tasks {
+		if !remove[t.ID] {
+			kept = append(kept, t)
+		}
+	}
+	m.tasks = kept
+	return m.save()
+}
+
+// ToggleAll completes the tasks with ids, or reopens them when all of them
+// are already done.
+func (m *Manager) ToggleAll(ids []int) error {
+	allDone := true
+	for _, id := range ids {
+		t, err := m.Get(id)
+		if err != nil {
+			return err
+		}
+		allDone = allDone && t.IsDone()
+	}
+	return m.UpdateAll(ids, func(t *Task) {
+		switch {
+		case allDone:
+			t.Status = StatusPending
+			t.Completed = time.Time{}
+		case !t.IsDone():
+			t.Done()
+		}
+	})
+}
+
+// Selection tracks the tasks marked in a TUI list, one by one or as a visual
+// range from an anchor to the cursor.
+type Selection struct {
+	marked map[int]bool
+	visual bool
+	anchor int
+}
+
+// Toggle marks or unmarks the task with id.
+func (s *Selection) Toggle(id int) {
+	if s.marked == nil {
+		s.marked = map[int]bool{}
+	}
+	if s.marked[id] {
+		delete(s.marked, id)
+	} else {
+		s.marked[id] = true
+	}
+}
+
+// StartVisual starts a visual range at cursor.
+func (s *Selection) StartVisual(cursor int) {
+	s.visual = true
+	s.anchor = cursor
+}
+
+// EndVisual marks the tasks of the visual range and leaves visual mode.
+func (s *Selection) EndVisual(tasks []*Task, cursor int) {
+	for _, id := range s.rangeIDs(tasks, cursor) {
+		if s.marked == nil {
+			s.marked = map[int]bool{}
+		}
+		s.marked[id] = true
+	}
+	s.visual = false
+}
+
+func (s Selection) Visual() bool {
+	return s.visual
+}
+
+// Active reports whether any task is marked or a visual range is shown.
+func (s Selection) Active() bool {
+	return s.visual || len(s.marked) > 0
+}
+
+func (s *Selection) Clear() {
+	s.marked = nil
+	s.visual = false
+}
+
+func (s Selection) rangeIDs(tasks []*Task, cursor int) []int {
+	if !s.visual {
+		return nil
+	}
+	from, to := min(s.anchor, cursor), max(s.anchor, cursor)
+	var ids []int
+	for i := max(from, 0); i <= to && i < len(tasks); i++ {
+		ids = append(ids, tasks[i].ID)
+	}
+	return ids
+}
+
+// HasMark reports whether the task with id is marked, ignoring the visual range.
+func (s Selection) HasMark(id int) bool {
+	return s.marked[id]
+}
+
+// IsMarked reports whether tasks[i] is marked or inside the visual range.
+func (s Selection) IsMarked(tasks []*Task, i, cursor int) bool {
+	if s.marked[tasks[i].ID] {
+		return true
+	}
+	return s.visual && i >= min(s.anchor, cursor) && i <= max(s.anchor, cursor)
+}
+
+// IDs returns the marked tasks and the visual range within tasks, in ID
+// order. Marks on tasks that no longer exist in all are dropped.
+func (s Selection) IDs(all []*Task, tasks []*Task, cursor int) []int {
+	exists := make(map[int]bool, len(all))
+	for _, t := range all {
+		exists[t.ID] = true
+	}
+	seen := map[int]bool{}
+	var ids []int
+	add := func(id int) {
+		if exists[id] && !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	for id := range s.marked {
+		add(id)
+	}
+	for _, id := range s.rangeIDs(tasks, cursor) {
+		add(id)
+	}
+	sort.Ints(ids)
+	return ids
+}
@@ -0,0 +1,184 @@
+package task
+
+import (
+	"os"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseIDs(t *testing.T) {
+	tests := []struct {
+		args    []string
+		want    IDSelection
+		wantErr bool
+	}{
+		{[]string{"3"}, IDSelection{IDs: []int{3}}, false},
+		{[]string{"3", "5", "7-10"}, IDSelection{IDs: []int{3, 5}, Ranges: [][2]int{{7, 10}}}, false},
+		{[]string{"3,5"}, IDSelection{IDs: []int{3, 5}}, false},
+		{[]string{"4-4"}, IDSelection{Ranges: [][2]int{{4, 4}}}, false},
+		{[]string{"abc"}, IDSelection{}, true},
+		{[]string{"0"}, IDSelection{}, true},
+		{[]string{"-3"}, IDSelection{}, true},
+		{[]string{"10-7"}, IDSelection{}, true},
+		{[]string{"7-x"}, IDSelection{}, true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseIDs(tt.args)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseIDs(%v) error = %v, wantErr %v", tt.args, err, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("ParseIDs(%v) = %+v, want %+v", tt.args, got, tt.want)
+		}
+	}
+}
+
+func taskIDs(tasks []*Task) []int {
+	ids := []int{}
+	for _, t := range tasks {
+		ids = append(ids, t.ID)
+	}
+	return ids
+}
+
+func TestManagerSelect(t *testing.T) {
+	manager, tmpDir := setupTestManager(t)
+	defer os.RemoveAll(tmpDir)
+
+	yesterday := time.Now().AddDate(0, 0, -1)
+	manager.Add("タスク1", PriorityHigh, "", yesterday)
+	manager.Add("タスク2", PriorityLow, "メモ", time.Time{})
+	manager.Add("タスク3", PriorityHigh, "", yesterday)
+	manager.Add("タスク4", PriorityMedium, "メモ", time.Time{})
+	manager.SetTags(2, []string{"work/a"})
+	manager.SetTags(3, []string{"work", "home"})
+	manager.SetProject(4, "p")
+	manager.Done(3)
+
+	ranges, _ := ParseIDs([]string{"2-10"})
+	tests := []struct {
+		name   string
+		sel    IDSelection
+		filter Filter
+		want   []int
+	}{
+		{"all", IDSelection{}, Filter{}, []int{1, 2, 3, 4}},
+		{"ids", IDSelection{IDs: []int{4, 1}}, Filter{}, []int{1, 4}},
+		{"range skips missing", ranges, Filter{}, []int{2, 3, 4}},
+		{"overdue excludes done", IDSelection{}, Filter{Overdue: true}, []int{1}},
+		{"nested tag", IDSelection{}, Filter{Tags: []string{"work"}}, []int{2, 3}},
+		{"all tags", IDSelection{}, Filter{Tags: []string{"work", "home"}}, []int{3}},
+		{"project", IDSelection{}, Filter{Project: "p"}, []int{4}},
+		{"priority", IDSelection{}, Filter{Priority: PriorityHigh}, []int{1, 3}},
+		{"note", IDSelection{}, Filter{NoteID: "メモ"}, []int{2, 4}},
+		{"ids and filter", ranges, Filter{NoteID: "メモ"}, []int{2, 4}},
+	}
+
+	for _, tt := range tests {
+		got, err := manager.Select(tt.sel, tt.filter)
+		if err != nil {
+			t.Errorf("%s: Select() error = %v", tt.name, err)
+			continue
+		}
+		if ids := taskIDs(got); !reflect.DeepEqual(ids, tt.want) {
+			t.Errorf("%s: Select() = %v, want %v", tt.name, ids, tt.want)
+		}
+	}
+
+	if _, err := manager.Select(IDSelection{IDs: []int{99}}, Filter{}); err == nil {
+		t.Error("Select() with an unknown ID should fail")
+	}
+}
+
+func TestManagerBulkUpdates(t *testing.T) {
+	manager, tmpDir := setupTestManager(t)
+	defer os.RemoveAll(tmpDir)
+
+	for i := 0; i < 4; i++ {
+		manager.Add("タスク", PriorityLow, "", time.Time{})
+	}
+
+	if err := manager.UpdateAll([]int{1, 2}, func(t *Task) { t.Priority = PriorityHigh }); err != nil {
+		t.Fatalf("UpdateAll() error = %v", err)
+	}
+	for _, id := range []int{1, 2} {
+		if task, _ := manager.Get(id); task.Priority != PriorityHigh {
+			t.Errorf("task %d priority = %v, want P1", id, task.Priority)
+		}
+	}
+
+	if err := manager.UpdateAll([]int{3, 99}, func(t *Task) { t.Priority = PriorityHigh }); err == nil {
+		t.Error("UpdateAll() with an unknown ID should fail")
+	}
+	if task, _ := manager.Get(3); task.Priority != PriorityLow {
+		t.Error("UpdateAll() with an unknown ID should not change other tasks")
+	}
+
+	// 一部が未完了なら全件を完了、全件完了済みなら未完了に戻す
+	manager.Done(1)
+	if err := manager.ToggleAll([]int{1, 2}); err != nil {
+		t.Fatalf("ToggleAll() error = %v", err)
+	}
+	task1, _ := manager.Get(1)
+	task2, _ := manager.Get(2)
+	if !task1.IsDone() || !task2.IsDone() {
+		t.Error("ToggleAll() should complete every task when some are pending")
+	}
+	manager.ToggleAll([]int{1, 2})
+	if task1.IsDone() || task2.IsDone() || !task1.Completed.IsZero() {
+		t.Error("ToggleAll() should reopen the tasks when all are done")
+	}
+
+	if err := manager.DeleteAll([]int{2, 99}); err == nil {
+		t.Error("DeleteAll() with an unknown ID should fail")
+	}
+	if err := manager.DeleteAll([]int{2, 4}); err != nil {
+		t.Fatalf("DeleteAll() error = %v", err)
+	}
+	if ids := taskIDs(manager.List(true)); len(ids) != 2 {
+		t.Errorf("after DeleteAll, tasks = %v, want 2 tasks", ids)
+	}
+
+	reloaded, _ := NewManager(tmpDir)
+	if len(reloaded.List(true)) != 2 {
+		t.Error("DeleteAll() should save the result")
+	}
+}
+
+func TestSelection(t *testing.T) {
+	tasks := []*Task{{ID: 5}, {ID: 3}, {ID: 8}, {ID: 1}}
+	var s Selection
+
+	if s.Active() || len(s.IDs(tasks, tasks, 0)) != 0 {
+		t.Fatal("empty selection should not be active")
+	}
+
+	s.Toggle(8)
+	s.StartVisual(0)
+	if !s.IsMarked(tasks, 0, 1) || !s.IsMarked(tasks, 1, 1) || !s.IsMarked(tasks, 2, 1) || s.IsMarked(tasks, 3, 1) {
+		t.Error("IsMarked() should cover the marked task and the visual range")
+	}
+	if got := s.IDs(tasks, tasks, 1); !reflect.DeepEqual(got, []int{3, 5, 8}) {
+		t.Errorf("IDs() = %v, want [3 5 8]", got)
+	}
+
+	// 範囲を確定すると印として残る
+	s.EndVisual(tasks, 1)
+	if s.Visual() || !s.IsMarked(tasks, 1, 3) {
+		t.Error("EndVisual() should keep the range marked")
+	}
+
+	// 存在しなくなったタスクの印は無視する
+	if got := s.IDs(tasks[1:], tasks[1:], 0); !reflect.DeepEqual(got, []int{3, 8}) {
+		t.Errorf("IDs() after removal = %v, want [3 8]", got)
+	}
+
+	s.Toggle(5)
+	s.Clear()
+	if s.Active() {
+		t.Error("Clear() should remove every mark")
+	}
+}
@@ -0,0 +1,344 @@
+package task
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	icalProdID       = "-//intiramisu//note-cli//JA"
+	icalUIDDomain    = "note-cli"
+	icalUIDPrefix    = "task-"
+	icalDateLayout   = "20060102"
+	icalUTCLayout    = "20060102T150405Z"
+	icalLocalLayout  = "20060102T150405"
+	icalMaxLineOctet = 75
+	icalPropNote     = "X-NOTE-CLI-NOTE"
+	icalPropProject  = "X-NOTE-CLI-PROJECT"
+)
+
+// WriteICS writes tasks as an iCalendar (RFC 5545) with one VTODO per task.
+// When withEvents is true, an all-day VEVENT is added for each due date.
+func WriteICS(w io.Writer, tasks []*Task, withEvents bool) error {
+	bw := bufio.NewWriter(w)
+	stamp := time.Now().UTC().Format(icalUTCLayout)
+
+	line := func(s string) {
+		bw.WriteString(foldICSLine(s))
+		bw.WriteString("\r\n")
+	}
+
+	line("BEGIN:VCALENDAR")
+	line("VERSION:2.0")
+	line("PRODID:" + icalProdID)
+	line("CALSCALE:GREGORIAN")
+
+	for _, t := range tasks {
+		line("BEGIN:VTODO")
+		line("UID:" + icsUID(t.EnsureUUID(), ""))
+		line("DTSTAMP:" + stamp)
+		if !t.Created.IsZero() {
+			line("CREATED:" + t.Created.UTC().Format(icalUTCLayout))
+		}
+		line("SUMMARY:" + escapeICSText(t.Description))
+		if p := icsPriority(t.Priority); p > 0 {
+			line("PRIORITY:" + strconv.Itoa(p))
+		}
+		if t.HasDueDate() {
+			line("DUE;VALUE=DATE:" + t.DueDate.Format(icalDateLayout))
+		}
+		if t.IsDone() {
+			line("STATUS:COMPLETED")
+			if !t.Completed.IsZero() {
+				line("COMPLETED:" + t.Completed.UTC().Format(icalUTCLayout))
+			}
+		} else {
+			line("STATUS:NEEDS-ACTION")
+		}
+		if len(t.Tags) > 0 {
+			escaped := make([]string, len(t.Tags))
+			for i, tag := range t.Tags {
+				escaped[i] = escapeICSText(tag)
+			}
+			line("CATEGORIES:" + strings.Join(escaped, ","))
+		}
+		if t.Project != "" {
+			line(icalPropProject + ":" + escapeICSText(t.Project))
+		}
+		if t.HasNote() {
+			line(icalPropNote + ":" + escapeICSText(t.NoteID))
+		}
+		line("END:VTODO")
+
+		if withEvents && t.HasDueDate() {
+			line("BEGIN:VEVENT")
+			line("UID:" + icsUID(t.UUID, "due"))
+			line("DTSTAMP:" + stamp)
+			line("DTSTART;VALUE=DATE:" + t.DueDate.Format(icalDateLayout))
+			line("DTEND;VALUE=DATE:" + t.DueDate.AddDate(0, 0, 1).Format(icalDateLayout))
+			line("SUMMARY:" + escapeICSText("期限: "+t.Description))
+			line("TRANSP:TRANSPARENT")
+			line("END:VEVENT")
+		}
+	}
+
+	line("END:VCALENDAR")
+	return bw.Flush()
+}
+
+// ReadICS reads VTODO components from an iCalendar. Tasks exported by
+// note-cli get their UUID back from the UID so re-import updates them.
+func ReadICS(r io.Reader) ([]*Task, error) {
+	lines, err := unfoldICSLines(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var tasks []*Task
+	var cur *Task
+	for _, l := range lines {
+		name, params, value := parseICSLine(l)
+		switch {
+		case name == "BEGIN" && value == "VTODO":
+			cur = &Task{Status: StatusPending}
+			continue
+		case name == "END" && value == "VTODO":
+			if cur != nil && cur.Description != "" {
+				tasks = append(tasks, cur)
+			}
+			cur = nil
+			continue
+		}
+		if cur == nil {
+			continue
+		}
+
+		switch name {
+		case "UID":
+			cur.UUID = uuidFromICSUID(value)
+		case "SUMMARY":
+			cur.Description = unescapeICSText(value)
+		case "PRIORITY":
+			n, _ := strconv.Atoi(value)
+			cur.Priority = priorityFromICS(n)
+		case "DUE":
+			cur.DueDate = parseICSTime(value, params, true)
+		case "CREATED":
+			cur.Created = parseICSTime(value, params, false)
+		case "COMPLETED":
+			cur.Completed = parseICSTime(value, params, false)
+		case "STATUS":
+			if value == "COMPLETED" {
+				cur.Status = StatusDone
+			}
+		case "CATEGORIES":
+			for _, c := range splitICSList(value) {
+				if c != "" {
+					cur.Tags = append(cur.Tags, c)
+				}
+			}
+		case icalPropProject:
+			cur.Project = unescapeICSText(value)
+		case icalPropNote:
+			cur.NoteID = unescapeICSText(value)
+		}
+	}
+	return tasks, nil
+}
+
+// icsUID builds the UID from the task UUID, so UIDs never collide with
+// tasks exported from another tasks file.
+func icsUID(uuid, suffix string) string {
+	uid := icalUIDPrefix + uuid
+	if suffix != "" {
+		uid += "-" + suffix
+	}
+	return uid + "@" + icalUIDDomain
+}
+
+// uuidFromICSUID returns the task UUID of a UID written by icsUID, or "" for
+// other UIDs (including the ID-based UIDs of older versions).
+func uuidFromICSUID(uid string) string {
+	local, domain, ok := strings.Cut(uid, "@")
+	if !ok || domain != icalUIDDomain || !strings.HasPrefix(local, icalUIDPrefix) {
+		return ""
+	}
+	uuid := strings.TrimPrefix(local, icalUIDPrefix)
+	if _, err := strconv.Atoi(uuid); err == nil {
+		return ""
+	}
+	return uuid
+}
+
+// icsPriority maps priorities to RFC 5545 values (1 = highest, 9 = lowest).
+func icsPriority(p Priority) int {
+	switch p {
+	case PriorityHigh:
+		return 1
+	case PriorityMedium:
+		return 5
+	case PriorityLow:
+		return 9
+	default:
+		return 0
+	}
+}
+
+func priorityFromICS(n int) Priority {
+	switch {
+	case n <= 0:
+		return PriorityNone
+	case n <= 4:
+		return PriorityHigh
+	case n == 5:
+		return PriorityMedium
+	default:
+		return PriorityLow
+	}
+}
+
+func parseICSTime(value string, params map[string]string, isDue bool) time.Time {
+	if params["VALUE"] == "DATE" || len(value) == len(icalDateLayout) {
+		d, err := time.ParseInLocation(icalDateLayout, value, time.Local)
+		if err != nil {
+			return time.Time{}
+		}
+		if isDue {
+			return time.Date(d.Year(), d.Month(), d.Day(), 23, 59, 59, 0, time.Local)
+		}
+		return d
+	}
+	if strings.HasSuffix(value, "Z") {
+		t, err := time.Parse(icalUTCLayout, value)
+		if err != nil {
+			return time.Time{}
+		}
+		return t.Local()
+	}
+	// TZID 付きの時刻はローカル時刻として扱う
+	t, err := time.ParseInLocation(icalLocalLayout, value, time.Local)
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
+
+// parseICSLine splits "NAME;PARAM=V:value" into its parts. ":" and ";"
+// inside quoted parameter values (ALTREP="http://...") do not split.
+func parseICSLine(l string) (name string, params map[string]string, value string) {
+	var parts []string
+	start, quoted := 0, false
+	end := len(l)
+	for i := 0; i < len(l); i++ {
+		c := l[i]
+		if c == '"' {
+			quoted = !quoted
+		}
+		if quoted || (c != ';' && c != ':') {
+			continue
+		}
+		parts = append(parts, l[start:i])
+		start = i + 1
+		if c == ':' {
+			end = i
+			break
+		}
+	}
+	if end == len(l) {
+		parts = append(parts, l[start:])
+	} else {
+		value = l[end+1:]
+	}
+
+	name = strings.ToUpper(parts[0])
+	params = make(map[string]string)
+	for _, p := range parts[1:] {
+		k, v, _ := strings.Cut(p, "=")
+		params[strings.ToUpper(k)] = strings.Trim(v, `"`)
+	}
+	return name, params, value
+}
+
+func unfoldICSLines(r io.Reader) ([]string, error) {
+	var lines []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		l := strings.TrimRight(scanner.Text(), "\r")
+		if (strings.HasPrefix(l, " ") || strings.HasPrefix(l, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1] += l[1:]
+			continue
+		}
+		if l != "" {
+			lines = append(lines, l)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("iCalendar の読み込みに失敗: %w", err)
+	}
+	return lines, nil
+}
+
+// foldICSLine folds a content line at 75 octets without splitting UTF-8 characters.
+func foldICSLine(s string) string {
+	if len(s) <= icalMaxLineOctet {
+		return s
+	}
+	var b strings.Builder
+	lineLen := 0
+	limit := icalMaxLineOctet
+	for _, r := range s {
+		size := len(string(r))
+		if lineLen+size > limit {
+			b.WriteString("\r\n ")
+			lineLen = 0
+			limit = icalMaxLineOctet - 1 // 継続行の先頭スペース分
+		}
+		b.WriteRune(r)
+		lineLen += size
+	}
+	return b.String()
+}
+
+var icsEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\n", `\n`)
+
+func escapeICSText(s string) string {
+	return icsEscaper.Replace(s)
+}
+
+func unescapeICSText(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+1 < len(s) {
+			i++
+			switch s[i] {
+			case 'n', 'N':
+				b.WriteByte('\n')
+			default:
+				b.WriteByte(s[i])
+			}
+			continue
+		}
+		b.WriteByte(s[i])
+	}
+	return b.String()
+}
+
+// splitICSList splits a comma separated list, honouring escaped commas.
+func splitICSList(s string) []string {
+	var items []string
+	start := 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case ',':
+			items = append(items, unescapeICSText(s[start:i]))
+			start = i + 1
+		}
+	}
+	return append(items, unescapeICSText(s[start:]))
+}
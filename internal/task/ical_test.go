@@ -0,0 +1,180 @@
+package task
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWriteICS(t *testing.T) {
+	tasks := []*Task{
+		{
+			ID:          3,
+			UUID:        "0b6c2a5e-3d1f-4c4e-9a7b-2f8e1d0c9b11",
+			Description: "レポート提出, 第1版; 下書き",
+			Priority:    PriorityHigh,
+			Tags:        []string{"仕事", "a,b"},
+			NoteID:      "週次MTG.md",
+			DueDate:     time.Date(2026, 1, 20, 23, 59, 59, 0, time.Local),
+			Created:     time.Date(2026, 1, 10, 9, 0, 0, 0, time.UTC),
+		},
+		{
+			ID:          4,
+			UUID:        "6f1e9d2c-8a4b-4b3d-8c2e-1a9f0e7d6c22",
+			Description: "買い物",
+			Status:      StatusDone,
+			Completed:   time.Date(2026, 1, 11, 18, 0, 0, 0, time.UTC),
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteICS(&buf, tasks, true); err != nil {
+		t.Fatalf("WriteICS() error = %v", err)
+	}
+	out := buf.String()
+
+	for _, want := range []string{
+		"BEGIN:VCALENDAR\r\n",
+		"UID:task-0b6c2a5e-3d1f-4c4e-9a7b-2f8e1d0c9b11@note-cli\r\n",
+		"SUMMARY:レポート提出\\, 第1版\\; 下書き\r\n",
+		"PRIORITY:1\r\n",
+		"DUE;VALUE=DATE:20260120\r\n",
+		"STATUS:NEEDS-ACTION\r\n",
+		"CATEGORIES:仕事,a\\,b\r\n",
+		"X-NOTE-CLI-NOTE:週次MTG.md\r\n",
+		"CREATED:20260110T090000Z\r\n",
+		"UID:task-6f1e9d2c-8a4b-4b3d-8c2e-1a9f0e7d6c22@note-cli\r\n",
+		"STATUS:COMPLETED\r\n",
+		"COMPLETED:20260111T180000Z\r\n",
+		"UID:task-0b6c2a5e-3d1f-4c4e-9a7b-2f8e1d0c9b11-due@note-cli\r\n",
+		"DTSTART;VALUE=DATE:20260120\r\n",
+		"END:VCALENDAR\r\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output should contain %q\n%s", want, out)
+		}
+	}
+
+	if strings.Count(out, "BEGIN:VEVENT") != 1 {
+		t.Errorf("expected exactly one VEVENT (only task 3 has a due date)")
+	}
+}
+
+func TestWriteICSWithoutEvents(t *testing.T) {
+	tasks := []*Task{{ID: 1, Description: "x", DueDate: time.Now()}}
+
+	var buf bytes.Buffer
+	WriteICS(&buf, tasks, false)
+	if strings.Contains(buf.String(), "VEVENT") {
+		t.Error("VEVENT should not be written when withEvents is false")
+	}
+}
+
+func TestFoldICSLine(t *testing.T) {
+	long := "SUMMARY:" + strings.Repeat("あ", 40)
+	folded := foldICSLine(long)
+
+	for _, l := range strings.Split(folded, "\r\n") {
+		if len(l) > icalMaxLineOctet {
+			t.Errorf("folded line is %d octets, want <= %d", len(l), icalMaxLineOctet)
+		}
+	}
+
+	lines, err := unfoldICSLines(strings.NewReader(folded + "\r\n"))
+	if err != nil {
+		t.Fatalf("unfoldICSLines() error = %v", err)
+	}
+	if len(lines) != 1 || lines[0] != long {
+		t.Errorf("unfold(fold(s)) = %q, want %q", lines, long)
+	}
+}
+
+func TestReadICS(t *testing.T) {
+	input := strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"BEGIN:VEVENT",
+		"UID:ignored",
+		"SUMMARY:イベント",
+		"END:VEVENT",
+		"BEGIN:VTODO",
+		"UID:task-0b6c2a5e-3d1f-4c4e-9a7b-2f8e1d0c9b11@note-cli",
+		"SUMMARY;ALTREP=\"http://example.com/a;b\":長いタイトル",
+		"  の続き",
+		"PRIORITY:3",
+		"DUE;TZID=Asia/Tokyo:20260120T100000",
+		"STATUS:COMPLETED",
+		"COMPLETED:20260119T010000Z",
+		"CATEGORIES:a,b\\,c",
+		"X-NOTE-CLI-PROJECT:proj",
+		"END:VTODO",
+		"BEGIN:VTODO",
+		"UID:task-7@note-cli",
+		"SUMMARY:外部タスク",
+		"DUE;VALUE=DATE:20260201",
+		"PRIORITY:7",
+		"END:VTODO",
+		"END:VCALENDAR",
+	}, "\r\n")
+
+	tasks, err := ReadICS(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("ReadICS() error = %v", err)
+	}
+	if len(tasks) != 2 {
+		t.Fatalf("ReadICS() returned %d tasks, want 2", len(tasks))
+	}
+
+	t1 := tasks[0]
+	if t1.UUID != "0b6c2a5e-3d1f-4c4e-9a7b-2f8e1d0c9b11" || t1.Description != "長いタイトル の続き" {
+		t.Errorf("task 1 = %+v", t1)
+	}
+	if t1.Priority != PriorityHigh || !t1.IsDone() || t1.Completed.IsZero() {
+		t.Errorf("task 1 priority/status = %v/%v", t1.Priority, t1.Status)
+	}
+	if t1.DueDate.Hour() != 10 {
+		t.Errorf("task 1 DueDate = %v, want 10:00 local", t1.DueDate)
+	}
+	if len(t1.Tags) != 2 || t1.Tags[1] != "b,c" || t1.Project != "proj" {
+		t.Errorf("task 1 tags/project = %v/%q", t1.Tags, t1.Project)
+	}
+
+	t2 := tasks[1]
+	if t2.ID != 0 || t2.UUID != "" {
+		t.Errorf("ID-based UID should not map to a task, got %d/%q", t2.ID, t2.UUID)
+	}
+	if t2.Priority != PriorityLow || t2.IsDone() {
+		t.Errorf("task 2 = %+v", t2)
+	}
+	wantDue := time.Date(2026, 2, 1, 23, 59, 59, 0, time.Local)
+	if !t2.DueDate.Equal(wantDue) {
+		t.Errorf("task 2 DueDate = %v, want %v", t2.DueDate, wantDue)
+	}
+}
+
+func TestICSRoundTrip(t *testing.T) {
+	orig := &Task{
+		ID:          9,
+		Description: "往復\nテスト",
+		Priority:    PriorityMedium,
+		Project:     "p",
+		Tags:        []string{"x"},
+		NoteID:      "メモ",
+		DueDate:     time.Date(2026, 3, 1, 23, 59, 59, 0, time.Local),
+		Created:     time.Date(2026, 2, 1, 12, 0, 0, 0, time.Local),
+	}
+
+	var buf bytes.Buffer
+	WriteICS(&buf, []*Task{orig}, true)
+	tasks, err := ReadICS(&buf)
+	if err != nil || len(tasks) != 1 {
+		t.Fatalf("ReadICS() = %v, %v", tasks, err)
+	}
+
+	got := tasks[0]
+	if got.UUID != orig.UUID || got.Description != orig.Description || got.Priority != orig.Priority ||
+		got.Project != orig.Project || got.NoteID != orig.NoteID || len(got.Tags) != 1 ||
+		!got.DueDate.Equal(orig.DueDate) || !got.Created.Equal(orig.Created) {
+		t.Errorf("round trip mismatch:\n got  %+v\n want %+v", got, orig)
+	}
+}
@@ -0,0 +1,193 @@
+package task
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/intiramisu/note-cli/internal/util"
+)
+
+// keyMap holds the key bindings of the task TUI. They can be overridden in
+// the keys.task config section.
+type keyMap struct {
+	Quit      key.Binding
+	Help      key.Binding
+	Palette   key.Binding
+	Up        key.Binding
+	Down      key.Binding
+	Left      key.Binding
+	Right     key.Binding
+	MoveLeft  key.Binding
+	MoveRight key.Binding
+	MoveUp    key.Binding
+	MoveDown  key.Binding
+	Toggle    key.Binding
+	Add       key.Binding
+	Delete    key.Binding
+	Sort      key.Binding
+	Agenda    key.Binding
+
+	// 絞り込み
+	Filter      key.Binding
+	Views       key.Binding
+	ClearFilter key.Binding
+
+	// 複数選択・一括操作
+	Mark           key.Binding
+	Visual         key.Binding
+	Unmark         key.Binding
+	PriorityHigh   key.Binding
+	PriorityMedium key.Binding
+	PriorityLow    key.Binding
+	Due            key.Binding
+	LinkNote       key.Binding
+
+	// 確認
+	Yes key.Binding
+	No  key.Binding
+
+	// コマンドパレットなど文字入力中の操作
+	ForceQuit  key.Binding
+	FilterDown key.Binding
+	FilterUp   key.Binding
+	NextItem   key.Binding
+	PrevItem   key.Binding
+
+	// タスク入力
+	Confirm      key.Binding
+	Cancel       key.Binding
+	NextPriority key.Binding
+	PrevPriority key.Binding
+	SetDue       key.Binding
+}
+
+// newKeyMap returns the default bindings with the configured overrides applied.
+func newKeyMap(overrides map[string][]string) (keyMap, error) {
+	k := keyMap{
+		Quit:      util.NewBinding("", "終了", "q", "ctrl+c"),
+		Help:      util.NewBinding("", "ヘルプ", "?"),
+		Palette:   util.NewBinding(":/Ctrl+P", "コマンド", ":", "ctrl+p"),
+		Up:        util.NewBinding("", "上へ", "k", "up"),
+		Down:      util.NewBinding("", "下へ", "j", "down"),
+		Left:      util.NewBinding("", "左へ", "h", "left"),
+		Right:     util.NewBinding("", "右へ", "l", "right"),
+		MoveLeft:  util.NewBinding("", "左の列へ移動", "H", "shift+left"),
+		MoveRight: util.NewBinding("", "右の列へ移動", "L", "shift+right"),
+		MoveUp:    util.NewBinding("", "上へ並べ替え", "K", "shift+up"),
+		MoveDown:  util.NewBinding("", "下へ並べ替え", "J", "shift+down"),
+		Toggle:    util.NewBinding("", "完了切替", "enter"),
+		Add:       util.NewBinding("", "追加", "i"),
+		Delete:    util.NewBinding("", "削除", "d", "x"),
+		Sort:      util.NewBinding("", "並び順", "s"),
+		Agenda:    util.NewBinding("", "アジェンダ", "a"),
+
+		Filter:      util.NewBinding("", "クエリで絞り込み", "/"),
+		Views:       util.NewBinding("", "ビューを選択", "f"),
+		ClearFilter: util.NewBinding("", "絞り込み解除", "esc"),
+
+		Mark:           util.NewBinding("", "印を付ける/外す", " "),
+		Visual:         util.NewBinding("", "範囲選択", "v"),
+		Unmark:         util.NewBinding("", "選択解除", "esc"),
+		PriorityHigh:   util.NewBinding("", "優先度をP1に", "1"),
+		PriorityMedium: util.NewBinding("", "優先度をP2に", "2"),
+		PriorityLow:    util.NewBinding("", "優先度をP3に", "3"),
+		Due:            util.NewBinding("", "期限を変更", "t"),
+		LinkNote:       util.NewBinding("", "メモに紐づけ", "m"),
+
+		Yes: util.NewBinding("", "はい", "y", "Y"),
+		No:  util.NewBinding("", "いいえ", "n", "N", "esc", "q"),
+
+		ForceQuit:  util.NewBinding("", "閉じる", "ctrl+c"),
+		FilterDown: util.NewBinding("", "下へ", "down", "ctrl+n", "ctrl+j"),
+		FilterUp:   util.NewBinding("", "上へ", "up", "ctrl+p", "ctrl+k"),
+		NextItem:   util.NewBinding("", "次へ", "tab"),
+		PrevItem:   util.NewBinding("", "前へ", "shift+tab"),
+
+		Confirm:      util.NewBinding("", "確定", "enter"),
+		Cancel:       util.NewBinding("", "キャンセル", "esc"),
+		NextPriority: util.NewBinding("", "優先度変更", "tab"),
+		PrevPriority: util.NewBinding("", "優先度を戻す", "shift+tab"),
+		SetDue:       util.NewBinding("", "期限設定", "ctrl+d"),
+	}
+	err := util.ApplyKeys(k.bindings(), overrides)
+	return k, err
+}
+
+// paletteKeys returns the bindings used inside the command palette.
+func (k keyMap) paletteKeys() util.PaletteKeys {
+	return util.PaletteKeys{
+		Confirm: k.Confirm,
+		Cancel:  k.Cancel,
+		Quit:    k.ForceQuit,
+		Up:      k.FilterUp,
+		Down:    k.FilterDown,
+		Next:    k.NextItem,
+		Prev:    k.PrevItem,
+	}
+}
+
+// bindings maps the action names used in the keys.task config section to the bindings.
+func (k *keyMap) bindings() map[string]*key.Binding {
+	return map[string]*key.Binding{
+		"quit":            &k.Quit,
+		"help":            &k.Help,
+		"palette":         &k.Palette,
+		"up":              &k.Up,
+		"down":            &k.Down,
+		"left":            &k.Left,
+		"right":           &k.Right,
+		"move_left":       &k.MoveLeft,
+		"move_right":      &k.MoveRight,
+		"move_up":         &k.MoveUp,
+		"move_down":       &k.MoveDown,
+		"toggle":          &k.Toggle,
+		"add":             &k.Add,
+		"delete":          &k.Delete,
+		"sort":            &k.Sort,
+		"agenda":          &k.Agenda,
+		"filter":          &k.Filter,
+		"views":           &k.Views,
+		"clear_filter":    &k.ClearFilter,
+		"mark":            &k.Mark,
+		"visual":          &k.Visual,
+		"unmark":          &k.Unmark,
+		"priority_high":   &k.PriorityHigh,
+		"priority_medium": &k.PriorityMedium,
+		"priority_low":    &k.PriorityLow,
+		"due":             &k.Due,
+		"link_note":       &k.LinkNote,
+		"yes":             &k.Yes,
+		"no":              &k.No,
+		"force_quit":      &k.ForceQuit,
+		"filter_down":     &k.FilterDown,
+		"filter_up":       &k.FilterUp,
+		"next_item":       &k.NextItem,
+		"prev_item":       &k.PrevItem,
+		"confirm":         &k.Confirm,
+		"cancel":          &k.Cancel,
+		"next_priority":   &k.NextPriority,
+		"prev_priority":   &k.PrevPriority,
+		"set_due":         &k.SetDue,
+	}
+}
+
+// helpEntry is one "keys:description" item of the help line.
+type helpEntry struct {
+	desc     string
+	bindings []key.Binding
+}
+
+func helpItem(desc string, bindings ...key.Binding) helpEntry {
+	return helpEntry{desc: desc, bindings: bindings}
+}
+
+// helpLine joins the entries with spaces, leaving out disabled bindings.
+func helpLine(entries ...helpEntry) string {
+	var parts []string
+	for _, e := range entries {
+		if keys := util.HelpKeys(e.bindings...); keys != "" {
+			parts = append(parts, keys+":"+e.desc)
+		}
+	}
+	return strings.Join(parts, " ")
+}
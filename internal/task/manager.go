@@ -8,13 +8,15 @@ import (
 	"time"
 
 	"github.com/intiramisu/note-cli/internal/config"
+	"github.com/intiramisu/note-cli/internal/util"
 	"gopkg.in/yaml.v3"
 )
 
 type Manager struct {
-	filePath string
-	tasks    []*Task
-	nextID   int
+	filePath   string
+	archiveDir string
+	tasks      []*Task
+	nextID     int
 }
 
 func NewManager(notesDir string) (*Manager, error) {
@@ -27,10 +29,16 @@ func NewManager(notesDir string) (*Manager, error) {
 		tasksFile = config.Global.Paths.TasksFile
 	}
 
+	archiveDir := ".archive"
+	if config.Global != nil && config.Global.Paths.ArchiveDir != "" {
+		archiveDir = config.Global.Paths.ArchiveDir
+	}
+
 	m := &Manager{
-		filePath: filepath.Join(notesDir, tasksFile),
-		tasks:    []*Task{},
-		nextID:   1,
+		filePath:   filepath.Join(notesDir, tasksFile),
+		archiveDir: filepath.Join(notesDir, archiveDir),
+		tasks:      []*Task{},
+		nextID:     1,
 	}
 
 	if err := m.load(); err != nil && !os.IsNotExist(err) {
@@ -41,13 +49,32 @@ func NewManager(notesDir string) (*Manager, error) {
 }
 
 func (m *Manager) Add(description string, priority Priority, noteID string, dueDate time.Time) *Task {
+	task, _ := m.AddWith(description, priority, noteID, dueDate, AddOptions{})
+	return task
+}
+
+// AddOptions holds the optional attributes of a task created by AddWith.
+type AddOptions struct {
+	Project string
+	Tags    []string
+}
+
+// AddWith adds a task with opts applied, saving the tasks file once. The task
+// is not kept when the save fails.
+func (m *Manager) AddWith(description string, priority Priority, noteID string, dueDate time.Time, opts AddOptions) (*Task, error) {
 	task := NewTask(m.nextID, description, priority)
 	task.NoteID = noteID
 	task.DueDate = dueDate
+	task.Project = opts.Project
+	task.Tags = opts.Tags
 	m.tasks = append(m.tasks, task)
 	m.nextID++
-	m.save()
-	return task
+	if err := m.save(); err != nil {
+		m.tasks = m.tasks[:len(m.tasks)-1]
+		m.nextID--
+		return nil, err
+	}
+	return task, nil
 }
 
 func (m *Manager) SetDueDate(id int, dueDate time.Time) error {
@@ -175,6 +202,228 @@ func (m *Manager) UnlinkNote(id int) error {
 	return m.SetNoteID(id, "")
 }
 
+// RenameTag renames old (and tags nested under it) to newTag in every active
+// task and returns the number of tasks changed. Archived tasks are left as is.
+func (m *Manager) RenameTag(old, newTag string) (int, error) {
+	count := 0
+	for _, t := range m.tasks {
+		if tags, changed := util.RenameTag(t.Tags, old, newTag); changed {
+			t.Tags = tags
+			count++
+		}
+	}
+	if count == 0 {
+		return 0, nil
+	}
+	return count, m.save()
+}
+
+// RelinkNote points every task linked to oldNoteID at newNoteID, e.g. after a
+// note is renamed, and returns how many tasks changed.
+func (m *Manager) RelinkNote(oldNoteID, newNoteID string) (int, error) {
+	count := 0
+	for _, t := range m.tasks {
+		if t.NoteID == oldNoteID {
+			t.NoteID = newNoteID
+			count++
+		}
+	}
+	if count == 0 {
+		return 0, nil
+	}
+	return count, m.save()
+}
+
+func (m *Manager) SetPriority(id int, priority Priority) error {
+	task, err := m.Get(id)
+	if err != nil {
+		return err
+	}
+	task.Priority = priority
+	return m.save()
+}
+
+func (m *Manager) SetProject(id int, project string) error {
+	task, err := m.Get(id)
+	if err != nil {
+		return err
+	}
+	task.Project = project
+	return m.save()
+}
+
+func (m *Manager) SetTags(id int, tags []string) error {
+	task, err := m.Get(id)
+	if err != nil {
+		return err
+	}
+	task.Tags = tags
+	return m.save()
+}
+
+// Reorder assigns manual ordering to the given tasks in the order of ids.
+func (m *Manager) Reorder(ids []int) error {
+	for i, id := range ids {
+		task, err := m.Get(id)
+		if err != nil {
+			return err
+		}
+		task.Order = i + 1
+	}
+	return m.save()
+}
+
+// sortByOrder sorts tasks by manual order (unordered last), then by priority.
+func sortByOrder(tasks []*Task) []*Task {
+	sort.SliceStable(tasks, func(i, j int) bool {
+		oi, oj := tasks[i].Order, tasks[j].Order
+		if oi != oj {
+			if oi == 0 {
+				return false
+			}
+			if oj == 0 {
+				return true
+			}
+			return oi < oj
+		}
+		if tasks[i].Priority != tasks[j].Priority {
+			return tasks[i].Priority > tasks[j].Priority
+		}
+		return tasks[i].Created.Before(tasks[j].Created)
+	})
+	return tasks
+}
+
+// Import merges tasks into the manager. An imported task matches an existing
+// task by UUID, else by identical description; IDs are never used since an
+// ID from another tasks file may belong to an unrelated task. Matched tasks
+// are updated in place, tasks whose UUID is archived are skipped, and the
+// rest are added with new IDs, so importing the same file twice does not
+// create duplicates.
+func (m *Manager) Import(tasks []*Task) (added, updated int, err error) {
+	archived, err := m.ListArchived()
+	if err != nil {
+		return 0, 0, err
+	}
+	matched := make(map[int]bool)
+
+	for _, in := range tasks {
+		existing, isArchived := m.findImportMatch(in, matched, archived)
+		if isArchived {
+			continue
+		}
+		if existing != nil {
+			matched[existing.ID] = true
+			mergeImported(existing, in)
+			updated++
+			continue
+		}
+
+		t := *in
+		t.ID = m.nextID
+		t.Order = 0
+		t.EnsureUUID()
+		if t.Created.IsZero() {
+			t.Created = time.Now()
+		}
+		if t.IsDone() && t.Completed.IsZero() {
+			t.Completed = time.Now()
+		}
+		m.tasks = append(m.tasks, &t)
+		matched[t.ID] = true
+		m.nextID++
+		added++
+	}
+
+	if added+updated == 0 {
+		return 0, 0, nil
+	}
+	return added, updated, m.save()
+}
+
+// findImportMatch returns the active task an imported task updates, or
+// reports that it matches an archived task.
+func (m *Manager) findImportMatch(in *Task, matched map[int]bool, archived []*Task) (*Task, bool) {
+	if in.UUID != "" {
+		for _, t := range m.tasks {
+			if !matched[t.ID] && t.UUID == in.UUID {
+				return t, false
+			}
+		}
+		for _, t := range archived {
+			if t.UUID == in.UUID {
+				return nil, true
+			}
+		}
+	}
+	// 説明文が同じアーカイブ済みタスクは、繰り返しのタスクかもしれないのでスキップしない
+	for _, t := range m.tasks {
+		if !matched[t.ID] && t.Description == in.Description {
+			return t, false
+		}
+	}
+	return nil, false
+}
+
+func mergeImported(dst, src *Task) {
+	dst.Description = src.Description
+	dst.Priority = src.Priority
+	dst.NoteID = src.NoteID
+	dst.Project = src.Project
+	dst.Tags = src.Tags
+	if src.UUID != "" {
+		dst.UUID = src.UUID
+	}
+	if len(src.Annotations) > 0 {
+		dst.Annotations = src.Annotations
+	}
+	if !sameDay(dst.DueDate, src.DueDate) {
+		dst.DueDate = src.DueDate
+	}
+	// 日付のみの形式から読み込んだ場合に時刻情報を失わないよう、同じ日なら既存値を保つ
+	if !src.Created.IsZero() && !sameDay(dst.Created, src.Created) {
+		dst.Created = src.Created
+	}
+
+	switch {
+	case !src.IsDone():
+		dst.Status = StatusPending
+		dst.Completed = time.Time{}
+	case !src.Completed.IsZero():
+		dst.Status = StatusDone
+		if !sameDay(dst.Completed, src.Completed) {
+			dst.Completed = src.Completed
+		}
+	case !dst.IsDone():
+		dst.Done()
+	}
+}
+
+func sameDay(a, b time.Time) bool {
+	if a.IsZero() || b.IsZero() {
+		return a.IsZero() == b.IsZero()
+	}
+	a, b = a.Local(), b.Local()
+	return a.Year() == b.Year() && a.YearDay() == b.YearDay()
+}
+
+// FilePath returns the path of the tasks file.
+func (m *Manager) FilePath() string {
+	return m.filePath
+}
+
+// Reload re-reads the tasks file to pick up changes made by another process.
+// On error the tasks loaded so far are kept.
+func (m *Manager) Reload() error {
+	err := m.load()
+	if os.IsNotExist(err) {
+		m.tasks = []*Task{}
+		m.nextID = 1
+		return nil
+	}
+	return err
+}
+
 func (m *Manager) load() error {
 	data, err := os.ReadFile(m.filePath)
 	if err != nil {
@@ -192,6 +441,18 @@ func (m *Manager) load() error {
 
 	m.tasks = stored.Tasks
 	m.nextID = stored.NextID
+
+	// UUID のない既存タスクに一度だけ割り当てる (保存できなければ次の保存時に書き込まれる)
+	assigned := false
+	for _, t := range m.tasks {
+		if t.UUID == "" {
+			t.EnsureUUID()
+			assigned = true
+		}
+	}
+	if assigned {
+		m.save()
+	}
 	return nil
 }
 
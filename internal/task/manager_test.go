@@ -124,6 +124,28 @@ func TestManagerAddFull(t *testing.T) {
 	}
 }
 
+func TestManagerAddWith(t *testing.T) {
+	manager, tmpDir := setupTestManager(t)
+	defer os.RemoveAll(tmpDir)
+
+	task, err := manager.AddWith("オプション付き", PriorityLow, "", time.Time{}, AddOptions{Project: "経理", Tags: []string{"work"}})
+	if err != nil {
+		t.Fatalf("AddWith() error = %v", err)
+	}
+
+	reloaded, err := NewManager(tmpDir)
+	if err != nil {
+		t.Fatalf("NewManager() error = %v", err)
+	}
+	got, err := reloaded.Get(task.ID)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if got.Project != "経理" || !got.HasTag("work") {
+		t.Errorf("After reload, Project = %q, Tags = %v", got.Project, got.Tags)
+	}
+}
+
 func TestManagerList(t *testing.T) {
 	manager, tmpDir := setupTestManager(t)
 	defer os.RemoveAll(tmpDir)
@@ -297,6 +319,65 @@ func TestManagerUnlinkNote(t *testing.T) {
 	}
 }
 
+func TestManagerSetPriority(t *testing.T) {
+	manager, tmpDir := setupTestManager(t)
+	defer os.RemoveAll(tmpDir)
+
+	manager.Add("タスク", PriorityLow, "", time.Time{})
+
+	if err := manager.SetPriority(1, PriorityHigh); err != nil {
+		t.Fatalf("SetPriority() error = %v", err)
+	}
+
+	got, _ := manager.Get(1)
+	if got.Priority != PriorityHigh {
+		t.Errorf("Priority = %v, want %v", got.Priority, PriorityHigh)
+	}
+
+	if err := manager.SetPriority(999, PriorityHigh); err == nil {
+		t.Error("SetPriority() should fail for unknown ID")
+	}
+}
+
+func TestManagerSetProjectAndTags(t *testing.T) {
+	manager, tmpDir := setupTestManager(t)
+	defer os.RemoveAll(tmpDir)
+
+	manager.Add("タスク", PriorityLow, "", time.Time{})
+	manager.SetProject(1, "note-cli")
+	manager.SetTags(1, []string{"bug", "ui"})
+
+	got, _ := manager.Get(1)
+	if got.Project != "note-cli" {
+		t.Errorf("Project = %q, want %q", got.Project, "note-cli")
+	}
+	if !got.HasTag("ui") || got.HasTag("docs") {
+		t.Errorf("Tags = %v, want [bug ui]", got.Tags)
+	}
+}
+
+func TestManagerReorder(t *testing.T) {
+	manager, tmpDir := setupTestManager(t)
+	defer os.RemoveAll(tmpDir)
+
+	manager.Add("タスク1", PriorityHigh, "", time.Time{})
+	manager.Add("タスク2", PriorityHigh, "", time.Time{})
+	manager.Add("タスク3", PriorityHigh, "", time.Time{})
+
+	if err := manager.Reorder([]int{3, 1, 2}); err != nil {
+		t.Fatalf("Reorder() error = %v", err)
+	}
+
+	sorted := sortByOrder(manager.List(true))
+	if sorted[0].ID != 3 || sorted[1].ID != 1 || sorted[2].ID != 2 {
+		t.Errorf("order = [%d %d %d], want [3 1 2]", sorted[0].ID, sorted[1].ID, sorted[2].ID)
+	}
+
+	if err := manager.Reorder([]int{999}); err == nil {
+		t.Error("Reorder() should fail for unknown ID")
+	}
+}
+
 func TestManagerPersistence(t *testing.T) {
 	tmpDir, err := os.MkdirTemp("", "note-cli-test-*")
 	if err != nil {
@@ -312,6 +393,8 @@ func TestManagerPersistence(t *testing.T) {
 
 	manager1.Add("タスク1", PriorityHigh, "", time.Time{})
 	manager1.Add("タスク2", PriorityLow, "メモ", time.Time{})
+	manager1.SetTags(2, []string{"work"})
+	manager1.Reorder([]int{2, 1})
 
 	// Create new manager (simulating restart)
 	manager2, err := NewManager(tmpDir)
@@ -328,4 +411,101 @@ func TestManagerPersistence(t *testing.T) {
 	if task2.NoteID != "メモ" {
 		t.Errorf("After reload, NoteID = %q, want %q", task2.NoteID, "メモ")
 	}
+	if !task2.HasTag("work") {
+		t.Errorf("After reload, Tags = %v, want [work]", task2.Tags)
+	}
+	if task2.Order != 1 {
+		t.Errorf("After reload, Order = %d, want 1", task2.Order)
+	}
+}
+
+func TestManagerReload(t *testing.T) {
+	manager1, tmpDir := setupTestManager(t)
+	defer os.RemoveAll(tmpDir)
+
+	manager1.Add("タスク1", PriorityHigh, "", time.Time{})
+
+	// 別プロセスでの追加を想定
+	manager2, err := NewManager(tmpDir)
+	if err != nil {
+		t.Fatalf("NewManager() error = %v", err)
+	}
+	manager2.Add("タスク2", PriorityLow, "", time.Time{})
+
+	if err := manager1.Reload(); err != nil {
+		t.Fatalf("Reload() error = %v", err)
+	}
+	if got := len(manager1.List(true)); got != 2 {
+		t.Errorf("After Reload, List() returned %d tasks, want 2", got)
+	}
+	if task := manager1.Add("タスク3", PriorityLow, "", time.Time{}); task.ID != 3 {
+		t.Errorf("After Reload, new task ID = %d, want 3", task.ID)
+	}
+
+	// 壊れたファイルでは読み込み済みのタスクを残す
+	if err := os.WriteFile(manager1.FilePath(), []byte("tasks: ["), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := manager1.Reload(); err == nil {
+		t.Error("Reload() of a broken file should fail")
+	}
+	if got := len(manager1.List(true)); got != 3 {
+		t.Errorf("After failed Reload, List() returned %d tasks, want 3", got)
+	}
+
+	os.Remove(manager1.FilePath())
+	if err := manager1.Reload(); err != nil {
+		t.Fatalf("Reload() of a removed file error = %v", err)
+	}
+	if got := len(manager1.List(true)); got != 0 {
+		t.Errorf("After removing the file, List() returned %d tasks, want 0", got)
+	}
+}
+
+func TestManagerRelinkNote(t *testing.T) {
+	manager, tmpDir := setupTestManager(t)
+	defer os.RemoveAll(tmpDir)
+
+	manager.Add("A", PriorityHigh, "old.md", time.Time{})
+	manager.Add("B", PriorityHigh, "old.md", time.Time{})
+	manager.Add("C", PriorityHigh, "other.md", time.Time{})
+
+	count, err := manager.RelinkNote("old.md", "new.md")
+	if err != nil {
+		t.Fatalf("RelinkNote() error = %v", err)
+	}
+	if count != 2 {
+		t.Errorf("RelinkNote() = %d, want 2", count)
+	}
+	if got := len(manager.ListByNote("new.md")); got != 2 {
+		t.Errorf("ListByNote(new.md) = %d tasks, want 2", got)
+	}
+	if got := len(manager.ListByNote("other.md")); got != 1 {
+		t.Errorf("ListByNote(other.md) = %d tasks, want 1", got)
+	}
+}
+
+func TestManagerRenameTag(t *testing.T) {
+	manager, tmpDir := setupTestManager(t)
+	defer os.RemoveAll(tmpDir)
+
+	a := manager.Add("A", PriorityHigh, "", time.Time{})
+	manager.SetTags(a.ID, []string{"work/projectA", "go"})
+	b := manager.Add("B", PriorityHigh, "", time.Time{})
+	manager.SetTags(b.ID, []string{"home"})
+
+	count, err := manager.RenameTag("work", "仕事")
+	if err != nil {
+		t.Fatalf("RenameTag() error = %v", err)
+	}
+	if count != 1 {
+		t.Errorf("RenameTag() = %d, want 1", count)
+	}
+	got, _ := manager.Get(a.ID)
+	if len(got.Tags) != 2 || got.Tags[0] != "仕事/projectA" {
+		t.Errorf("Tags = %v, want [仕事/projectA go]", got.Tags)
+	}
+	if !got.HasTag("仕事") {
+		t.Error("HasTag(仕事) should match 仕事/projectA")
+	}
 }
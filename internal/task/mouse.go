@@ -0,0 +1,97 @@
+package task
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/intiramisu/note-cli/internal/config"
+	"github.com/mattn/go-runewidth"
+)
+
+// sectionHit is the part of the board under the mouse pointer.
+type sectionHit struct {
+	section  int  // -1: セクションの外
+	task     int  // -1: 見出し・枠線などタスク以外
+	checkbox bool // タスク1行目のチェックボックス上
+}
+
+// hitTest maps a screen position to a section and task, following the
+// layout drawn by View.
+func (m Model) hitTest(x, y int) sectionHit {
+	hit := sectionHit{section: -1, task: -1}
+	colWidth, colHeight := m.calculateDimensions()
+	// 枠線を含む列の幅と高さ
+	outerWidth, outerHeight := colWidth+2, colHeight+2
+
+	top := lipgloss.Height(m.renderTitle()) + 1
+	if x < 0 || y < top || y >= top+outerHeight || x >= outerWidth*len(m.sections) {
+		return hit
+	}
+	hit.section = x / outerWidth
+
+	// 上の枠線と見出しの下からタスクが並ぶ
+	row := y - top - 2
+	if row < 0 {
+		return hit
+	}
+	symbols := config.Global.Theme.Symbols
+	checkboxX := hit.section*outerWidth + 2 + runewidth.StringWidth(symbols.CursorEmpty)
+	for i, t := range m.sections[hit.section].tasks {
+		height := lipgloss.Height(m.renderTaskLine(t, colWidth, false, false))
+		if row < height {
+			hit.task = i
+			hit.checkbox = row == 0 && x >= checkboxX && x < checkboxX+runewidth.StringWidth(symbols.CheckboxEmpty)
+			return hit
+		}
+		row -= height
+	}
+	return hit
+}
+
+// updateMouse selects the clicked task (toggling it on its checkbox), focuses
+// a section by its header and moves the cursor with the wheel.
+func (m Model) updateMouse(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
+	if m.showHelp {
+		m.help, _ = m.help.Update(msg)
+		return m, nil
+	}
+	if m.paletteOpen {
+		return m.updatePalette(msg)
+	}
+	if m.mode != modeNormal || m.confirmIDs != nil {
+		return m, nil
+	}
+
+	hit := m.hitTest(msg.X, msg.Y)
+	switch msg.Button {
+	case tea.MouseButtonWheelUp, tea.MouseButtonWheelDown:
+		if hit.section >= 0 && hit.section != m.sectionIdx {
+			m.keepVisual(func() {
+				m.sectionIdx = hit.section
+				m.adjustCursor()
+			})
+		}
+		if msg.Button == tea.MouseButtonWheelUp {
+			m.moveUp()
+		} else {
+			m.moveDown()
+		}
+
+	case tea.MouseButtonLeft:
+		if msg.Action != tea.MouseActionPress || hit.section < 0 {
+			return m, nil
+		}
+		if hit.task < 0 {
+			// 見出しのクリックはその列の先頭タスクへ (空の列は選べない)
+			if len(m.sections[hit.section].tasks) > 0 {
+				m.keepVisual(func() { m.sectionIdx, m.taskIdx = hit.section, 0 })
+			}
+			return m, nil
+		}
+		m.keepVisual(func() { m.sectionIdx, m.taskIdx = hit.section, hit.task })
+		// チェックボックスは印に関係なくそのタスクだけを切り替える
+		if hit.checkbox && m.keys.Toggle.Enabled() {
+			m.apply([]int{m.currentTask().ID}, m.manager.ToggleAll)
+		}
+	}
+	return m, nil
+}
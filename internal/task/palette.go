@@ -0,0 +1,142 @@
+package task
+
+import (
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/intiramisu/note-cli/internal/util"
+)
+
+// paletteAction is an entry of the command palette.
+type paletteAction struct {
+	name     string
+	keywords string
+	binding  key.Binding
+	run      func(m *Model) tea.Cmd
+}
+
+func (m Model) helpSections() []util.HelpSection {
+	k := m.keys
+	return []util.HelpSection{
+		{Title: "移動", Bindings: []key.Binding{k.Up, k.Down, k.Left, k.Right}},
+		{Title: "タスク", Bindings: []key.Binding{k.Toggle, k.Add, k.Delete, k.PriorityHigh, k.PriorityMedium, k.PriorityLow, k.Due, k.LinkNote, k.MoveLeft, k.MoveRight, k.MoveUp, k.MoveDown}},
+		{Title: "複数選択", Bindings: []key.Binding{k.Mark, k.Visual, k.Unmark}},
+		{Title: "表示", Bindings: []key.Binding{k.Sort, k.Agenda, k.Filter, k.Views, k.ClearFilter}},
+		{Title: "タスク入力", Bindings: []key.Binding{k.Confirm, k.Cancel, k.NextPriority, k.PrevPriority, k.SetDue}},
+		{Title: "全般", Bindings: []key.Binding{k.Help, k.Palette, k.Quit}},
+	}
+}
+
+func (m *Model) openHelp() {
+	m.help = util.NewHelpView("ヘルプ - タスク管理", m.helpSections(), styles, m.width, m.height)
+	m.showHelp = true
+}
+
+func (m Model) updateHelp(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if key.Matches(msg, m.keys.Help) {
+		m.showHelp = false
+		return m, nil
+	}
+	var closed bool
+	m.help, closed = m.help.Update(msg)
+	if closed {
+		m.showHelp = false
+	}
+	return m, nil
+}
+
+// paletteActions lists the actions available in the current view; disabled
+// bindings are left out.
+func (m Model) paletteActions() []paletteAction {
+	k := m.keys
+	actions := []paletteAction{
+		{"タスクを追加", "add new", k.Add, func(m *Model) tea.Cmd { return m.startAdd() }},
+	}
+	if m.currentTask() != nil {
+		visualName := "範囲選択を開始"
+		if m.sel.Visual() {
+			visualName = "範囲選択を終了"
+		}
+		actions = append(actions,
+			paletteAction{"完了を切替", "toggle done", k.Toggle, func(m *Model) tea.Cmd { m.toggleTargets(); return nil }},
+			paletteAction{"タスクを削除", "delete remove", k.Delete, func(m *Model) tea.Cmd { m.deleteTargets(); return nil }},
+			paletteAction{"優先度を P1 にする", "priority high", k.PriorityHigh, func(m *Model) tea.Cmd { m.setPriority(PriorityHigh); return nil }},
+			paletteAction{"優先度を P2 にする", "priority medium", k.PriorityMedium, func(m *Model) tea.Cmd { m.setPriority(PriorityMedium); return nil }},
+			paletteAction{"優先度を P3 にする", "priority low", k.PriorityLow, func(m *Model) tea.Cmd { m.setPriority(PriorityLow); return nil }},
+			paletteAction{"期限を変更", "due date", k.Due, func(m *Model) tea.Cmd { return m.startDue() }},
+			paletteAction{"メモに紐づけ", "link note", k.LinkNote, func(m *Model) tea.Cmd { return m.startLink() }},
+			paletteAction{"印を付ける/外す", "mark select", k.Mark, func(m *Model) tea.Cmd { m.toggleMark(); return nil }},
+			paletteAction{visualName, "visual select range", k.Visual, func(m *Model) tea.Cmd { m.toggleVisual(); return nil }},
+		)
+	}
+	if m.sel.Active() {
+		actions = append(actions,
+			paletteAction{"選択を解除", "unmark clear selection", k.Unmark, func(m *Model) tea.Cmd { m.sel.Clear(); return nil }},
+		)
+	}
+
+	sortName := "期限順に並べる"
+	if m.sortByDue && !m.agendaView {
+		sortName = "優先度順に並べる"
+	}
+	agendaName := "アジェンダ表示に切替"
+	if m.agendaView {
+		agendaName = "通常表示に切替"
+	}
+	quitName := "終了"
+	if m.embedded {
+		quitName = "タスクボードを閉じる"
+	}
+	actions = append(actions,
+		paletteAction{"クエリで絞り込み", "filter query search", k.Filter, func(m *Model) tea.Cmd { return m.startFilter() }},
+		paletteAction{"ビューを選択", "view saved filter", k.Views, func(m *Model) tea.Cmd { return m.startViews() }},
+	)
+	if m.query != nil {
+		actions = append(actions,
+			paletteAction{"絞り込みを解除", "clear filter", k.ClearFilter, func(m *Model) tea.Cmd { m.setQuery(nil, ""); return nil }},
+		)
+	}
+	actions = append(actions,
+		paletteAction{sortName, "sort", k.Sort, func(m *Model) tea.Cmd { m.toggleSort(); return nil }},
+		paletteAction{agendaName, "agenda view", k.Agenda, func(m *Model) tea.Cmd { m.toggleAgenda(); return nil }},
+		paletteAction{"ヘルプを表示", "help keys", k.Help, func(m *Model) tea.Cmd { m.openHelp(); return nil }},
+		paletteAction{quitName, "quit exit close", k.Quit, func(m *Model) tea.Cmd { return m.quit() }},
+	)
+
+	var enabled []paletteAction
+	for _, a := range actions {
+		if a.binding.Enabled() {
+			enabled = append(enabled, a)
+		}
+	}
+	return enabled
+}
+
+func (m *Model) openPalette() tea.Cmd {
+	m.actions = m.paletteActions()
+	items := make([]util.PaletteItem, len(m.actions))
+	for i, a := range m.actions {
+		items[i] = util.PaletteItem{Name: a.name, Keywords: a.keywords, Key: util.HelpKeys(a.binding)}
+	}
+	m.paletteOpen = true
+	return m.palette.Open(items)
+}
+
+func (m Model) updatePalette(msg tea.Msg) (tea.Model, tea.Cmd) {
+	chosen, closed, cmd := m.palette.Update(msg)
+	if !closed {
+		return m, cmd
+	}
+	m.paletteOpen = false
+	if m.linkIDs != nil {
+		m.finishLink(chosen)
+		return m, nil
+	}
+	if m.viewNames != nil {
+		m.finishViews(chosen)
+		return m, nil
+	}
+	if chosen < 0 {
+		return m, nil
+	}
+	return m, m.actions[chosen].run(&m)
+}
@@ -0,0 +1,434 @@
+package task
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/intiramisu/note-cli/internal/util"
+)
+
+// Query is a parsed task filter expression such as
+//
+//	priority>=P2 and due<+7d and note:"週次MTG" and not done
+//
+// Conditions are joined with and/or (adjacent conditions mean and), negated
+// with not and grouped with parentheses. A bare word or quoted string matches
+// the description.
+type Query struct {
+	src          string
+	match        func(t *Task) bool
+	checksStatus bool
+}
+
+// ParseQuery parses s. Relative dates such as today or +7d are resolved at
+// parse time. An empty query matches every task.
+func ParseQuery(s string) (*Query, error) {
+	tokens, err := lexQuery(s)
+	if err != nil {
+		return nil, err
+	}
+	q := &Query{src: strings.TrimSpace(s)}
+	p := &queryParser{tokens: tokens, query: q, now: time.Now()}
+	if p.peek().kind == tokEOF {
+		q.match = func(*Task) bool { return true }
+		return q, nil
+	}
+	match, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.kind != tokEOF {
+		return nil, tok.errorf("予期しない %q", tok.text)
+	}
+	q.match = match
+	return q, nil
+}
+
+func (q *Query) Match(t *Task) bool {
+	return q.match(t)
+}
+
+func (q *Query) String() string {
+	return q.src
+}
+
+// And returns a query matching tasks that match both q and other.
+func (q *Query) And(other *Query) *Query {
+	return &Query{
+		src:          "(" + q.src + ") and (" + other.src + ")",
+		match:        func(t *Task) bool { return q.match(t) && other.match(t) },
+		checksStatus: q.checksStatus || other.checksStatus,
+	}
+}
+
+// ChecksStatus reports whether the query selects tasks by done/pending, so
+// done tasks should not be hidden before it is applied.
+func (q *Query) ChecksStatus() bool {
+	return q != nil && q.checksStatus
+}
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokWord
+	tokString
+	tokOp
+	tokLParen
+	tokRParen
+)
+
+type queryToken struct {
+	kind tokenKind
+	text string
+	pos  int // 先頭からの文字数 (エラー表示用)
+}
+
+func (t queryToken) errorf(format string, args ...any) error {
+	return fmt.Errorf("無効なクエリです (%d文字目): %s", t.pos+1, fmt.Sprintf(format, args...))
+}
+
+func isOpRune(r rune) bool {
+	return strings.ContainsRune(":=!<>", r)
+}
+
+func lexQuery(s string) ([]queryToken, error) {
+	runes := []rune(s)
+	var tokens []queryToken
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+
+		case r == '(' || r == ')':
+			kind := tokLParen
+			if r == ')' {
+				kind = tokRParen
+			}
+			tokens = append(tokens, queryToken{kind: kind, text: string(r), pos: i})
+			i++
+
+		case r == '"':
+			start := i
+			var b strings.Builder
+			for i++; i < len(runes) && runes[i] != '"'; i++ {
+				if runes[i] == '\\' && i+1 < len(runes) {
+					i++
+				}
+				b.WriteRune(runes[i])
+			}
+			if i >= len(runes) {
+				return nil, queryToken{pos: start}.errorf("引用符が閉じていません")
+			}
+			tokens = append(tokens, queryToken{kind: tokString, text: b.String(), pos: start})
+			i++
+
+		case isOpRune(r):
+			start := i
+			op := string(r)
+			if i+1 < len(runes) && runes[i+1] == '=' && r != ':' && r != '=' {
+				op += "="
+			}
+			if op == "!" {
+				return nil, queryToken{pos: start}.errorf("! の後には = が必要です")
+			}
+			tokens = append(tokens, queryToken{kind: tokOp, text: op, pos: start})
+			i += len([]rune(op))
+
+		default:
+			start := i
+			for i < len(runes) && !unicode.IsSpace(runes[i]) && !isOpRune(runes[i]) && !strings.ContainsRune(`()"`, runes[i]) {
+				i++
+			}
+			tokens = append(tokens, queryToken{kind: tokWord, text: string(runes[start:i]), pos: start})
+		}
+	}
+	return append(tokens, queryToken{kind: tokEOF, pos: len(runes)}), nil
+}
+
+type queryParser struct {
+	tokens []queryToken
+	pos    int
+	query  *Query
+	now    time.Time
+}
+
+func (p *queryParser) peek() queryToken {
+	return p.tokens[p.pos]
+}
+
+func (p *queryParser) next() queryToken {
+	tok := p.tokens[p.pos]
+	if tok.kind != tokEOF {
+		p.pos++
+	}
+	return tok
+}
+
+func (p *queryParser) keyword(tok queryToken, word string) bool {
+	return tok.kind == tokWord && strings.EqualFold(tok.text, word)
+}
+
+func (p *queryParser) parseOr() (func(*Task) bool, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.keyword(p.peek(), "or") {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		l := left
+		left = func(t *Task) bool { return l(t) || right(t) }
+	}
+	return left, nil
+}
+
+func (p *queryParser) parseAnd() (func(*Task) bool, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		tok := p.peek()
+		if p.keyword(tok, "and") {
+			p.next()
+		} else if tok.kind == tokEOF || tok.kind == tokRParen || p.keyword(tok, "or") {
+			return left, nil
+		}
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		l := left
+		left = func(t *Task) bool { return l(t) && right(t) }
+	}
+}
+
+func (p *queryParser) parseUnary() (func(*Task) bool, error) {
+	tok := p.next()
+	switch {
+	case p.keyword(tok, "not"):
+		inner, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return func(t *Task) bool { return !inner(t) }, nil
+
+	case tok.kind == tokLParen:
+		inner, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if closing := p.next(); closing.kind != tokRParen {
+			return nil, closing.errorf("閉じ括弧がありません")
+		}
+		return inner, nil
+
+	case tok.kind == tokString:
+		return matchText(tok.text), nil
+
+	case tok.kind == tokWord:
+		if p.peek().kind == tokOp {
+			op := p.next()
+			value := p.next()
+			if value.kind != tokWord && value.kind != tokString {
+				return nil, value.errorf("%s%s の後に値が必要です", tok.text, op.text)
+			}
+			return p.parseCondition(tok, op, value)
+		}
+		return p.parseKeyword(tok), nil
+
+	case tok.kind == tokEOF:
+		return nil, tok.errorf("条件が必要です")
+	}
+	return nil, tok.errorf("予期しない %q", tok.text)
+}
+
+// parseKeyword handles a bare word: a status or else a description search.
+func (p *queryParser) parseKeyword(tok queryToken) func(*Task) bool {
+	if match, ok := p.statusMatch(tok.text); ok {
+		return match
+	}
+	return matchText(tok.text)
+}
+
+func (p *queryParser) statusMatch(s string) (func(*Task) bool, bool) {
+	switch strings.ToLower(s) {
+	case "done", "completed":
+		p.query.checksStatus = true
+		return (*Task).IsDone, true
+	case "pending", "todo":
+		p.query.checksStatus = true
+		return func(t *Task) bool { return !t.IsDone() }, true
+	case "overdue":
+		return (*Task).IsOverdue, true
+	}
+	return nil, false
+}
+
+func matchText(s string) func(*Task) bool {
+	s = strings.ToLower(s)
+	return func(t *Task) bool {
+		return strings.Contains(strings.ToLower(t.Description), s)
+	}
+}
+
+// parseCondition builds field op value, e.g. priority>=P2 or tag:work.
+func (p *queryParser) parseCondition(field, op, value queryToken) (func(*Task) bool, error) {
+	name := strings.ToLower(field.text)
+	v := value.text
+
+	switch name {
+	case "priority", "pri", "p":
+		priority, ok := parseQueryPriority(v)
+		if !ok {
+			return nil, value.errorf("無効な優先度: %s", v)
+		}
+		return compareOp(op, func(t *Task) (int, bool) { return int(t.Priority), true }, int(priority))
+
+	case "due", "created", "completed":
+		if name == "completed" {
+			p.query.checksStatus = true
+		}
+		get := func(t *Task) time.Time {
+			switch name {
+			case "due":
+				return t.DueDate
+			case "created":
+				return t.Created
+			}
+			return t.Completed
+		}
+		if strings.EqualFold(v, "none") {
+			return equalityOp(op, func(t *Task) bool { return get(t).IsZero() })
+		}
+		date, err := parseQueryDate(v, p.now)
+		if err != nil {
+			return nil, value.errorf("無効な日付: %s", v)
+		}
+		day := dayNumber(date)
+		return compareOp(op, func(t *Task) (int, bool) {
+			d := get(t)
+			return dayNumber(d), !d.IsZero()
+		}, day)
+
+	case "id":
+		id, err := strconv.Atoi(v)
+		if err != nil {
+			return nil, value.errorf("無効なID: %s", v)
+		}
+		return compareOp(op, func(t *Task) (int, bool) { return t.ID, true }, id)
+
+	case "note":
+		if strings.EqualFold(v, "none") {
+			return equalityOp(op, func(t *Task) bool { return !t.HasNote() })
+		}
+		want := strings.TrimSuffix(v, ".md")
+		return equalityOp(op, func(t *Task) bool {
+			return t.HasNote() && strings.EqualFold(strings.TrimSuffix(t.NoteID, ".md"), want)
+		})
+
+	case "tag":
+		if strings.EqualFold(v, "none") {
+			return equalityOp(op, func(t *Task) bool { return len(t.Tags) == 0 })
+		}
+		return equalityOp(op, func(t *Task) bool { return t.HasTag(v) })
+
+	case "project":
+		if strings.EqualFold(v, "none") {
+			return equalityOp(op, func(t *Task) bool { return t.Project == "" })
+		}
+		return equalityOp(op, func(t *Task) bool { return t.Project == v })
+
+	case "status", "is":
+		match, ok := p.statusMatch(v)
+		if !ok {
+			return nil, value.errorf("無効な状態: %s (done / pending / overdue)", v)
+		}
+		return equalityOp(op, match)
+
+	case "text", "desc":
+		return equalityOp(op, matchText(v))
+	}
+	return nil, field.errorf("不明な項目です: %s", field.text)
+}
+
+// equalityOp applies a condition that only supports : = and !=.
+func equalityOp(op queryToken, match func(*Task) bool) (func(*Task) bool, error) {
+	switch op.text {
+	case ":", "=":
+		return match, nil
+	case "!=":
+		return func(t *Task) bool { return !match(t) }, nil
+	}
+	return nil, op.errorf("%s は使えません (: = != のみ)", op.text)
+}
+
+// compareOp compares the value from get with want; tasks without a value
+// (ok is false) never match.
+func compareOp(op queryToken, get func(*Task) (int, bool), want int) (func(*Task) bool, error) {
+	var cmp func(a, b int) bool
+	switch op.text {
+	case ":", "=":
+		cmp = func(a, b int) bool { return a == b }
+	case "!=":
+		cmp = func(a, b int) bool { return a != b }
+	case "<":
+		cmp = func(a, b int) bool { return a < b }
+	case "<=":
+		cmp = func(a, b int) bool { return a <= b }
+	case ">":
+		cmp = func(a, b int) bool { return a > b }
+	case ">=":
+		cmp = func(a, b int) bool { return a >= b }
+	}
+	return func(t *Task) bool {
+		v, ok := get(t)
+		return ok && cmp(v, want)
+	}, nil
+}
+
+// parseQueryPriority accepts P1-P3, 1-3, high/medium/low and none. Higher
+// priorities compare greater, so priority>=P2 means P1 or P2.
+func parseQueryPriority(s string) (Priority, bool) {
+	s = strings.ToLower(s)
+	if s == "none" {
+		return PriorityNone, true
+	}
+	p := ParsePriority(strings.TrimPrefix(s, "p"))
+	return p, p != PriorityNone
+}
+
+var relativeDatePattern = regexp.MustCompile(`^([+-]\d+)([dw]?)$`)
+
+// parseQueryDate parses today, yesterday, +7d, -2w, +3 and the formats of
+// util.ParseDueDate.
+func parseQueryDate(s string, now time.Time) (time.Time, error) {
+	s = strings.ToLower(s)
+	if s == "yesterday" {
+		return now.AddDate(0, 0, -1), nil
+	}
+	if m := relativeDatePattern.FindStringSubmatch(s); m != nil {
+		n, _ := strconv.Atoi(m[1])
+		if m[2] == "w" {
+			n *= 7
+		}
+		return now.AddDate(0, 0, n), nil
+	}
+	return util.ParseDueDate(s)
+}
+
+// dayNumber converts t to a day count in local time so that dates compare by
+// calendar day.
+func dayNumber(t time.Time) int {
+	y, m, d := t.Local().Date()
+	return int(time.Date(y, m, d, 0, 0, 0, 0, time.UTC).Unix() / 86400)
+}
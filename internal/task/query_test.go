@@ -0,0 +1,124 @@
+package task
+
+import (
+	"testing"
+	"time"
+)
+
+func queryTestTasks() []*Task {
+	now := time.Now()
+	return []*Task{
+		{ID: 1, Description: "レビューを書く", Priority: PriorityHigh, DueDate: now.AddDate(0, 0, 3), NoteID: "週次MTG.md", Created: now},
+		{ID: 2, Description: "資料を準備", Priority: PriorityMedium, DueDate: now.AddDate(0, 0, -2), Tags: []string{"work/docs"}, Created: now},
+		{ID: 3, Description: "請求書", Priority: PriorityLow, DueDate: now.AddDate(0, 0, 10), Project: "経理", Created: now},
+		{ID: 4, Description: "Review PR", Priority: PriorityHigh, Status: StatusDone, Completed: now, NoteID: "週次MTG.md", Created: now.AddDate(0, 0, -30)},
+		{ID: 5, Description: "買い物", Created: now},
+	}
+}
+
+func TestQueryMatch(t *testing.T) {
+	tests := []struct {
+		query string
+		want  []int
+	}{
+		{"", []int{1, 2, 3, 4, 5}},
+		{`priority>=P2 and due<+7d and note:"週次MTG" and not done`, []int{1}},
+		{"priority>=P2", []int{1, 2, 4}},
+		{"p=3", []int{3}},
+		{"priority:high", []int{1, 4}},
+		{"priority<P2", []int{3, 5}},
+		{"priority:none", []int{5}},
+		{"due<today", []int{2}},
+		{"due<=+1w", []int{1, 2}},
+		{"due>=2000-01-01", []int{1, 2, 3}},
+		{"due:none", []int{4, 5}},
+		{"due!=none", []int{1, 2, 3}},
+		{"created<-7d", []int{4}},
+		{"completed:today", []int{4}},
+		{"note:週次MTG.md", []int{1, 4}},
+		{"note:none", []int{2, 3, 5}},
+		{"tag:work", []int{2}},
+		{"tag!=work", []int{1, 3, 4, 5}},
+		{"project:経理", []int{3}},
+		{"project:none", []int{1, 2, 4, 5}},
+		{"done", []int{4}},
+		{"is:pending", []int{1, 2, 3, 5}},
+		{"overdue", []int{2}},
+		{"review", []int{4}},
+		{`"を書く"`, []int{1}},
+		{"id>3", []int{4, 5}},
+		{"p:1 or tag:work", []int{1, 2, 4}},
+		{"p:1 or tag:work and not done", []int{1, 2, 4}},
+		{"(p:1 or tag:work) not done", []int{1, 2}},
+		{"NOT (p:1 OR p:2)", []int{3, 5}},
+	}
+
+	tasks := queryTestTasks()
+	for _, tt := range tests {
+		q, err := ParseQuery(tt.query)
+		if err != nil {
+			t.Errorf("ParseQuery(%q) error = %v", tt.query, err)
+			continue
+		}
+		got := []int{}
+		for _, task := range tasks {
+			if q.Match(task) {
+				got = append(got, task.ID)
+			}
+		}
+		if len(got) != len(tt.want) {
+			t.Errorf("ParseQuery(%q) matched %v, want %v", tt.query, got, tt.want)
+			continue
+		}
+		for i := range got {
+			if got[i] != tt.want[i] {
+				t.Errorf("ParseQuery(%q) matched %v, want %v", tt.query, got, tt.want)
+				break
+			}
+		}
+	}
+}
+
+func TestParseQueryErrors(t *testing.T) {
+	tests := []string{
+		"unknown:x",
+		"priority>=P9",
+		"due<someday",
+		"tag>work",
+		"(p:1 or p:2",
+		"p:1)",
+		`note:"週次`,
+		"p!1",
+		"not",
+		"tag:",
+	}
+
+	for _, query := range tests {
+		if _, err := ParseQuery(query); err == nil {
+			t.Errorf("ParseQuery(%q) should fail", query)
+		}
+	}
+}
+
+func TestQueryChecksStatus(t *testing.T) {
+	tests := []struct {
+		query string
+		want  bool
+	}{
+		{"p:1", false},
+		{"overdue", false},
+		{"not done", true},
+		{"is:pending", true},
+		{"completed>-7d", true},
+	}
+
+	for _, tt := range tests {
+		q, err := ParseQuery(tt.query)
+		if err != nil {
+			t.Fatalf("ParseQuery(%q) error = %v", tt.query, err)
+		}
+		if got := q.ChecksStatus(); got != tt.want {
+			t.Errorf("ParseQuery(%q).ChecksStatus() = %v, want %v", tt.query, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,244 @@
+package task
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/intiramisu/note-cli/internal/util"
+)
+
+// sectionTasks returns the tasks of the focused section.
+func (m *Model) sectionTasks() []*Task {
+	if m.sectionIdx >= len(m.sections) {
+		return nil
+	}
+	return m.sections[m.sectionIdx].tasks
+}
+
+func (m *Model) allTasks() []*Task {
+	var all []*Task
+	for _, section := range m.sections {
+		all = append(all, section.tasks...)
+	}
+	return all
+}
+
+// targetIDs returns the marked tasks, or the task under the cursor when
+// nothing is marked.
+func (m *Model) targetIDs() []int {
+	if m.sel.Active() {
+		return m.sel.IDs(m.allTasks(), m.sectionTasks(), m.taskIdx)
+	}
+	if task := m.currentTask(); task != nil {
+		return []int{task.ID}
+	}
+	return nil
+}
+
+// toggleMark marks or unmarks the current task and moves to the next one.
+func (m *Model) toggleMark() {
+	if task := m.currentTask(); task != nil {
+		m.sel.Toggle(task.ID)
+		m.moveDown()
+	}
+}
+
+func (m *Model) toggleVisual() {
+	if m.sel.Visual() {
+		m.sel.EndVisual(m.sectionTasks(), m.taskIdx)
+		return
+	}
+	if m.currentTask() != nil {
+		m.sel.StartVisual(m.taskIdx)
+	}
+}
+
+// keepVisual runs move and carries a visual range over to another section:
+// the range so far stays marked and a new one starts at the cursor.
+func (m *Model) keepVisual(move func()) {
+	if !m.sel.Visual() {
+		move()
+		return
+	}
+	section, tasks, cursor := m.sectionIdx, m.sectionTasks(), m.taskIdx
+	move()
+	if m.sectionIdx != section {
+		m.sel.EndVisual(tasks, cursor)
+		m.sel.StartVisual(m.taskIdx)
+	}
+}
+
+// apply runs fn on ids and keeps the cursor on the current task.
+func (m *Model) apply(ids []int, fn func(ids []int) error) {
+	if len(ids) == 0 {
+		return
+	}
+	var cursorID int
+	if task := m.currentTask(); task != nil {
+		cursorID = task.ID
+	}
+	if err := fn(ids); err != nil {
+		m.status = err.Error()
+		return
+	}
+	m.refreshTasks()
+	m.moveCursorToTask(cursorID)
+	m.adjustCursor()
+}
+
+// applyToTargets runs fn on the marked tasks (or the current one) and clears
+// the selection.
+func (m *Model) applyToTargets(fn func(ids []int) error) {
+	m.apply(m.targetIDs(), fn)
+	m.sel.Clear()
+}
+
+func (m *Model) setPriority(p Priority) {
+	m.applyToTargets(func(ids []int) error {
+		return m.manager.UpdateAll(ids, func(t *Task) { t.Priority = p })
+	})
+}
+
+// deleteTargets deletes the current task, or asks before deleting several.
+func (m *Model) deleteTargets() {
+	ids := m.targetIDs()
+	switch {
+	case len(ids) > 1:
+		m.confirmIDs = ids
+	case len(ids) == 1:
+		m.deleteTasks(ids)
+	}
+}
+
+func (m *Model) deleteTasks(ids []int) {
+	if err := m.manager.DeleteAll(ids); err != nil {
+		m.status = err.Error()
+		return
+	}
+	m.sel.Clear()
+	m.refreshTasks()
+	m.adjustCursor()
+}
+
+func (m Model) updateConfirmDelete(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, m.keys.Yes):
+		m.deleteTasks(m.confirmIDs)
+		m.confirmIDs = nil
+	case key.Matches(msg, m.keys.No):
+		m.confirmIDs = nil
+	}
+	return m, nil
+}
+
+// startDue prompts for the due date of the target tasks, filled in with the
+// current one when a single task is chosen.
+func (m *Model) startDue() tea.Cmd {
+	ids := m.targetIDs()
+	if len(ids) == 0 {
+		return nil
+	}
+	m.dueIDs = ids
+	m.mode = modeDue
+	m.dueInput.Reset()
+	if len(ids) == 1 {
+		if t, err := m.manager.Get(ids[0]); err == nil && t.HasDueDate() {
+			m.dueInput.SetValue(t.DueDate.Format("2006-01-02"))
+		}
+	}
+	m.dueInput.Focus()
+	return textinput.Blink
+}
+
+func (m *Model) endDue() {
+	m.mode = modeNormal
+	m.dueIDs = nil
+	m.dueInput.Reset()
+	m.dueInput.Blur()
+}
+
+// updateDueMode sets the entered due date; an empty value clears it.
+func (m Model) updateDueMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, m.keys.Confirm):
+		value := strings.TrimSpace(m.dueInput.Value())
+		due, err := util.ParseDueDate(value)
+		if err != nil {
+			m.status = "無効な日付です: " + value
+			return m, nil
+		}
+		m.apply(m.dueIDs, func(ids []int) error {
+			return m.manager.UpdateAll(ids, func(t *Task) { t.DueDate = due })
+		})
+		m.sel.Clear()
+		m.endDue()
+		return m, nil
+
+	case key.Matches(msg, m.keys.Cancel):
+		m.endDue()
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.dueInput, cmd = m.dueInput.Update(msg)
+	return m, cmd
+}
+
+// startLink lists the notes in the palette to link the target tasks to one
+// of them, or to unlink them.
+func (m *Model) startLink() tea.Cmd {
+	ids := m.targetIDs()
+	if len(ids) == 0 || m.storage == nil {
+		return nil
+	}
+	notes, err := m.storage.List("")
+	if err != nil {
+		m.status = err.Error()
+		return nil
+	}
+
+	items := []util.PaletteItem{{Name: "(紐づけを解除)", Keywords: "unlink none"}}
+	m.linkNotes = []string{""}
+	for _, n := range notes {
+		items = append(items, util.PaletteItem{Name: n.Title, Keywords: n.ID, Key: n.ID})
+		m.linkNotes = append(m.linkNotes, n.ID)
+	}
+	m.linkIDs = ids
+	m.paletteOpen = true
+	return m.palette.OpenList(fmt.Sprintf("紐づけるメモ (%d 件のタスク)", len(ids)), items)
+}
+
+// finishLink links the tasks chosen in startLink to the chosen note.
+func (m *Model) finishLink(chosen int) {
+	ids := m.linkIDs
+	m.linkIDs = nil
+	if chosen < 0 {
+		return
+	}
+	noteID := m.linkNotes[chosen]
+	m.apply(ids, func(ids []int) error {
+		return m.manager.UpdateAll(ids, func(t *Task) { t.NoteID = noteID })
+	})
+	m.sel.Clear()
+}
+
+// renderSelection draws the delete confirmation, the due date prompt or the
+// number of marked tasks below the board.
+func (m Model) renderSelection() string {
+	switch {
+	case m.confirmIDs != nil:
+		return "\n" + styles.Selected.Render(fmt.Sprintf("%d 件のタスクを削除しますか? %s", len(m.confirmIDs), util.YesNo(m.keys.Yes, m.keys.No)))
+	case m.mode == modeDue:
+		return fmt.Sprintf("\n期限 (%d 件, 空欄で解除): %s", len(m.dueIDs), m.dueInput.View())
+	case m.sel.Active():
+		label := fmt.Sprintf("%d 件を選択中", len(m.targetIDs()))
+		if m.sel.Visual() {
+			label += " (範囲選択)"
+		}
+		return "\n" + styles.Marked.Render(label)
+	}
+	return ""
+}
@@ -0,0 +1,170 @@
+package task
+
+import (
+	"sort"
+	"time"
+)
+
+// DayCount is the number of tasks completed on a day (or in a week starting that day).
+type DayCount struct {
+	Date  time.Time `json:"date"`
+	Count int       `json:"count"`
+}
+
+// GroupStat is the completion count of tasks sharing a key (priority, note).
+type GroupStat struct {
+	Key       string `json:"key"`
+	Total     int    `json:"total"`
+	Completed int    `json:"completed"`
+}
+
+// Stats is a productivity summary of tasks.
+type Stats struct {
+	Total            int           `json:"total"`
+	Completed        int           `json:"completed"`
+	Pending          int           `json:"pending"`
+	CompletedPerDay  []DayCount    `json:"completed_per_day"`
+	CompletedPerWeek []DayCount    `json:"completed_per_week"`
+	AverageLeadTime  time.Duration `json:"-"`
+	LeadTimeHours    float64       `json:"average_lead_time_hours"`
+	WithDueDate      int           `json:"with_due_date"`
+	Late             int           `json:"late"`
+	OverdueRate      float64       `json:"overdue_rate"`
+	ByPriority       []GroupStat   `json:"by_priority"`
+	ByNote           []GroupStat   `json:"by_note"`
+}
+
+// ComputeStats summarizes tasks. days is the number of days (ending at now)
+// covered by the per-day and per-week completion counts.
+func ComputeStats(tasks []*Task, days int, now time.Time) Stats {
+	var s Stats
+	if days < 1 {
+		days = 1
+	}
+
+	today := startOfDay(now)
+	from := today.AddDate(0, 0, -(days - 1))
+
+	perDay := make(map[time.Time]int)
+	byPriority := make(map[Priority]*GroupStat)
+	byNote := make(map[string]*GroupStat)
+	var leadTotal time.Duration
+	leadCount := 0
+
+	for _, t := range tasks {
+		s.Total++
+
+		ps, ok := byPriority[t.Priority]
+		if !ok {
+			ps = &GroupStat{Key: priorityKey(t.Priority)}
+			byPriority[t.Priority] = ps
+		}
+		ps.Total++
+
+		var ns *GroupStat
+		if t.HasNote() {
+			ns, ok = byNote[t.NoteID]
+			if !ok {
+				ns = &GroupStat{Key: t.NoteID}
+				byNote[t.NoteID] = ns
+			}
+			ns.Total++
+		}
+
+		if t.HasDueDate() {
+			s.WithDueDate++
+			if t.IsOverdue() || (t.IsDone() && t.Completed.After(t.DueDate)) {
+				s.Late++
+			}
+		}
+
+		if !t.IsDone() {
+			s.Pending++
+			continue
+		}
+
+		s.Completed++
+		ps.Completed++
+		if ns != nil {
+			ns.Completed++
+		}
+
+		if !t.Completed.IsZero() && !t.Created.IsZero() {
+			leadTotal += t.Completed.Sub(t.Created)
+			leadCount++
+		}
+
+		day := startOfDay(t.Completed.In(now.Location()))
+		if !day.Before(from) && !day.After(today) {
+			perDay[day]++
+		}
+	}
+
+	if leadCount > 0 {
+		s.AverageLeadTime = leadTotal / time.Duration(leadCount)
+		s.LeadTimeHours = s.AverageLeadTime.Hours()
+	}
+	if s.WithDueDate > 0 {
+		s.OverdueRate = float64(s.Late) / float64(s.WithDueDate)
+	}
+
+	weekIdx := make(map[time.Time]int)
+	for d := from; !d.After(today); d = d.AddDate(0, 0, 1) {
+		count := perDay[d]
+		s.CompletedPerDay = append(s.CompletedPerDay, DayCount{Date: d, Count: count})
+
+		week := startOfWeek(d)
+		i, ok := weekIdx[week]
+		if !ok {
+			i = len(s.CompletedPerWeek)
+			weekIdx[week] = i
+			s.CompletedPerWeek = append(s.CompletedPerWeek, DayCount{Date: week})
+		}
+		s.CompletedPerWeek[i].Count += count
+	}
+
+	for _, p := range []Priority{PriorityHigh, PriorityMedium, PriorityLow, PriorityNone} {
+		if ps, ok := byPriority[p]; ok {
+			s.ByPriority = append(s.ByPriority, *ps)
+		}
+	}
+
+	for _, ns := range byNote {
+		s.ByNote = append(s.ByNote, *ns)
+	}
+	sort.Slice(s.ByNote, func(i, j int) bool {
+		if s.ByNote[i].Completed != s.ByNote[j].Completed {
+			return s.ByNote[i].Completed > s.ByNote[j].Completed
+		}
+		return s.ByNote[i].Key < s.ByNote[j].Key
+	})
+
+	return s
+}
+
+// Stats returns a productivity summary of all tasks, including archived ones.
+func (m *Manager) Stats(days int) (Stats, error) {
+	archived, err := m.ListArchived()
+	if err != nil {
+		return Stats{}, err
+	}
+	tasks := append(append([]*Task{}, m.tasks...), archived...)
+	return ComputeStats(tasks, days, time.Now()), nil
+}
+
+func priorityKey(p Priority) string {
+	if p == PriorityNone {
+		return "-"
+	}
+	return p.String()
+}
+
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+// startOfWeek returns the Monday of the week containing t.
+func startOfWeek(t time.Time) time.Time {
+	offset := (int(t.Weekday()) + 6) % 7
+	return startOfDay(t).AddDate(0, 0, -offset)
+}
@@ -0,0 +1,110 @@
+package task
+
+import (
+	"testing"
+	"time"
+)
+
+func TestComputeStats(t *testing.T) {
+	now := time.Date(2026, 3, 11, 15, 0, 0, 0, time.Local) // Wednesday
+
+	newDone := func(id int, p Priority, created, completed time.Time) *Task {
+		task := NewTask(id, "done", p)
+		task.Created = created
+		task.Status = StatusDone
+		task.Completed = completed
+		return task
+	}
+
+	t1 := newDone(1, PriorityHigh, now.Add(-48*time.Hour), now.Add(-24*time.Hour))
+	t1.NoteID = "会議"
+	t1.DueDate = now.Add(-36 * time.Hour) // completed late
+	t2 := newDone(2, PriorityHigh, now.Add(-4*time.Hour), now.Add(-2*time.Hour))
+	t2.NoteID = "会議"
+	t3 := newDone(3, PriorityLow, now.AddDate(0, 0, -40), now.AddDate(0, 0, -30)) // outside window
+	t4 := NewTask(4, "pending", PriorityMedium)
+	t4.DueDate = time.Now().AddDate(0, 0, -1) // overdue
+	t5 := NewTask(5, "pending", PriorityNone)
+	t5.NoteID = "週報"
+
+	s := ComputeStats([]*Task{t1, t2, t3, t4, t5}, 7, now)
+
+	if s.Total != 5 || s.Completed != 3 || s.Pending != 2 {
+		t.Errorf("Total/Completed/Pending = %d/%d/%d, want 5/3/2", s.Total, s.Completed, s.Pending)
+	}
+
+	if len(s.CompletedPerDay) != 7 {
+		t.Fatalf("len(CompletedPerDay) = %d, want 7", len(s.CompletedPerDay))
+	}
+	last := s.CompletedPerDay[6]
+	if !last.Date.Equal(startOfDay(now)) || last.Count != 1 {
+		t.Errorf("today = %v/%d, want %v/1", last.Date, last.Count, startOfDay(now))
+	}
+	if s.CompletedPerDay[5].Count != 1 {
+		t.Errorf("yesterday count = %d, want 1", s.CompletedPerDay[5].Count)
+	}
+
+	weekTotal := 0
+	for _, w := range s.CompletedPerWeek {
+		if w.Date.Weekday() != time.Monday {
+			t.Errorf("week %v should start on Monday", w.Date)
+		}
+		weekTotal += w.Count
+	}
+	if weekTotal != 2 {
+		t.Errorf("sum of CompletedPerWeek = %d, want 2", weekTotal)
+	}
+
+	wantLead := (24*time.Hour + 2*time.Hour + 10*24*time.Hour) / 3
+	if s.AverageLeadTime != wantLead {
+		t.Errorf("AverageLeadTime = %v, want %v", s.AverageLeadTime, wantLead)
+	}
+
+	if s.WithDueDate != 2 || s.Late != 2 {
+		t.Errorf("WithDueDate/Late = %d/%d, want 2/2", s.WithDueDate, s.Late)
+	}
+	if s.OverdueRate != 1.0 {
+		t.Errorf("OverdueRate = %v, want 1.0", s.OverdueRate)
+	}
+
+	if len(s.ByPriority) != 4 {
+		t.Fatalf("len(ByPriority) = %d, want 4", len(s.ByPriority))
+	}
+	if s.ByPriority[0].Key != "P1" || s.ByPriority[0].Total != 2 || s.ByPriority[0].Completed != 2 {
+		t.Errorf("ByPriority[0] = %+v, want P1 2/2", s.ByPriority[0])
+	}
+	if s.ByPriority[3].Key != "-" {
+		t.Errorf("ByPriority[3].Key = %q, want %q", s.ByPriority[3].Key, "-")
+	}
+
+	if len(s.ByNote) != 2 || s.ByNote[0].Key != "会議" || s.ByNote[0].Completed != 2 {
+		t.Errorf("ByNote = %+v, want 会議 first with 2 completed", s.ByNote)
+	}
+}
+
+func TestComputeStatsEmpty(t *testing.T) {
+	s := ComputeStats(nil, 0, time.Now())
+
+	if s.Total != 0 || s.AverageLeadTime != 0 || s.OverdueRate != 0 {
+		t.Errorf("empty stats = %+v", s)
+	}
+	if len(s.CompletedPerDay) != 1 {
+		t.Errorf("len(CompletedPerDay) = %d, want 1 (days is clamped to 1)", len(s.CompletedPerDay))
+	}
+}
+
+func TestStartOfWeek(t *testing.T) {
+	tests := []struct {
+		day  time.Time
+		want time.Time
+	}{
+		{time.Date(2026, 3, 9, 10, 0, 0, 0, time.Local), time.Date(2026, 3, 9, 0, 0, 0, 0, time.Local)},  // Monday
+		{time.Date(2026, 3, 15, 23, 0, 0, 0, time.Local), time.Date(2026, 3, 9, 0, 0, 0, 0, time.Local)}, // Sunday
+	}
+
+	for _, tt := range tests {
+		if got := startOfWeek(tt.day); !got.Equal(tt.want) {
+			t.Errorf("startOfWeek(%v) = %v, want %v", tt.day, got, tt.want)
+		}
+	}
+}
@@ -1,7 +1,11 @@
 package task
 
 import (
+	"crypto/rand"
+	"fmt"
 	"time"
+
+	"github.com/intiramisu/note-cli/internal/util"
 )
 
 type Priority int
@@ -65,14 +69,25 @@ const (
 )
 
 type Task struct {
-	ID          int       `yaml:"id"`
+	ID          int          `yaml:"id"`
+	Description string       `yaml:"description"`
+	Priority    Priority     `yaml:"priority"`
+	Status      Status       `yaml:"status"`
+	NoteID      string       `yaml:"note_id,omitempty"`
+	Project     string       `yaml:"project,omitempty"`
+	Tags        []string     `yaml:"tags,omitempty"`
+	Order       int          `yaml:"order,omitempty"` // カンバン列内の手動並び順 (0 = 未設定)
+	DueDate     time.Time    `yaml:"due_date,omitempty"`
+	Created     time.Time    `yaml:"created"`
+	Completed   time.Time    `yaml:"completed,omitempty"`
+	UUID        string       `yaml:"uuid,omitempty"`        // 外部ツール (Taskwarrior) との対応付け用
+	Annotations []Annotation `yaml:"annotations,omitempty"` // Taskwarrior の注記
+}
+
+// Annotation is a timestamped note attached to a task.
+type Annotation struct {
+	Entry       time.Time `yaml:"entry"`
 	Description string    `yaml:"description"`
-	Priority    Priority  `yaml:"priority"`
-	Status      Status    `yaml:"status"`
-	NoteID      string    `yaml:"note_id,omitempty"`
-	DueDate     time.Time `yaml:"due_date,omitempty"`
-	Created     time.Time `yaml:"created"`
-	Completed   time.Time `yaml:"completed,omitempty"`
 }
 
 func NewTask(id int, description string, priority Priority) *Task {
@@ -82,6 +97,7 @@ func NewTask(id int, description string, priority Priority) *Task {
 		Priority:    priority,
 		Status:      StatusPending,
 		Created:     time.Now(),
+		UUID:        newUUID(),
 	}
 }
 
@@ -102,6 +118,16 @@ func (t *Task) HasNote() bool {
 	return t.NoteID != ""
 }
 
+// HasTag reports whether the task carries tag or a tag nested under it.
+func (t *Task) HasTag(tag string) bool {
+	for _, tg := range t.Tags {
+		if util.TagMatches(tg, tag) {
+			return true
+		}
+	}
+	return false
+}
+
 func (t *Task) SetDueDate(due time.Time) {
 	t.DueDate = due
 }
@@ -110,6 +136,24 @@ func (t *Task) HasDueDate() bool {
 	return !t.DueDate.IsZero()
 }
 
+// EnsureUUID returns the task's UUID, assigning a random one first when the
+// task has none (tasks created before UUIDs were stored).
+func (t *Task) EnsureUUID() string {
+	if t.UUID == "" {
+		t.UUID = newUUID()
+	}
+	return t.UUID
+}
+
+// newUUID returns a random (version 4) UUID.
+func newUUID() string {
+	var b [16]byte
+	rand.Read(b[:])
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
+
 func (t *Task) IsOverdue() bool {
 	if !t.HasDueDate() || t.IsDone() {
 		return false
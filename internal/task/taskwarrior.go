@@ -0,0 +1,176 @@
+package task
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+)
+
+const taskwarriorTimeLayout = "20060102T150405Z"
+
+// twTask is a task in Taskwarrior's `task export` JSON format.
+type twTask struct {
+	ID          int            `json:"id,omitempty"` // 作業用ID (インポート時は無視)
+	UUID        string         `json:"uuid"`
+	Description string         `json:"description"`
+	Status      string         `json:"status"`
+	Entry       string         `json:"entry,omitempty"`
+	Modified    string         `json:"modified,omitempty"`
+	End         string         `json:"end,omitempty"`
+	Due         string         `json:"due,omitempty"`
+	Priority    string         `json:"priority,omitempty"`
+	Project     string         `json:"project,omitempty"`
+	Tags        []string       `json:"tags,omitempty"`
+	Annotations []twAnnotation `json:"annotations,omitempty"`
+	Note        string         `json:"notecli_note,omitempty"` // UDA: 紐づきメモ
+}
+
+type twAnnotation struct {
+	Entry       string `json:"entry"`
+	Description string `json:"description"`
+}
+
+// WriteTaskwarrior writes tasks as a Taskwarrior import/export JSON array.
+func WriteTaskwarrior(w io.Writer, tasks []*Task) error {
+	out := make([]twTask, 0, len(tasks))
+	now := time.Now()
+	for _, t := range tasks {
+		tw := twTask{
+			ID:          t.ID,
+			UUID:        t.EnsureUUID(),
+			Description: t.Description,
+			Status:      "pending",
+			Entry:       formatTaskwarriorTime(t.Created),
+			Modified:    formatTaskwarriorTime(now),
+			Priority:    taskwarriorPriority(t.Priority),
+			Project:     t.Project,
+			Tags:        t.Tags,
+			Note:        t.NoteID,
+		}
+		if t.HasDueDate() {
+			tw.Due = formatTaskwarriorTime(t.DueDate)
+		}
+		if t.IsDone() {
+			tw.ID = 0 // Taskwarrior は完了タスクに作業用IDを振らない
+			tw.Status = "completed"
+			tw.End = formatTaskwarriorTime(t.Completed)
+		}
+		for _, a := range t.Annotations {
+			tw.Annotations = append(tw.Annotations, twAnnotation{
+				Entry:       formatTaskwarriorTime(a.Entry),
+				Description: a.Description,
+			})
+		}
+		out = append(out, tw)
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	enc.SetEscapeHTML(false)
+	return enc.Encode(out)
+}
+
+// ReadTaskwarrior reads Taskwarrior JSON: either an array (`task export`)
+// or one object per line (older versions). Deleted tasks are skipped.
+func ReadTaskwarrior(r io.Reader) ([]*Task, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("Taskwarrior JSON の読み込みに失敗: %w", err)
+	}
+
+	var items []twTask
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) > 0 && trimmed[0] == '[' {
+		if err := json.Unmarshal(trimmed, &items); err != nil {
+			return nil, fmt.Errorf("Taskwarrior JSON のパースに失敗: %w", err)
+		}
+	} else {
+		dec := json.NewDecoder(bytes.NewReader(trimmed))
+		for dec.More() {
+			var item twTask
+			if err := dec.Decode(&item); err != nil {
+				return nil, fmt.Errorf("Taskwarrior JSON のパースに失敗: %w", err)
+			}
+			items = append(items, item)
+		}
+	}
+
+	var tasks []*Task
+	for _, tw := range items {
+		if tw.Status == "deleted" || tw.Description == "" {
+			continue
+		}
+		t := &Task{
+			UUID:        tw.UUID,
+			Description: tw.Description,
+			Priority:    priorityFromTaskwarrior(tw.Priority),
+			Status:      StatusPending,
+			NoteID:      tw.Note,
+			Project:     tw.Project,
+			Tags:        tw.Tags,
+			Created:     parseTaskwarriorTime(tw.Entry),
+			DueDate:     parseTaskwarriorTime(tw.Due),
+		}
+		if tw.Status == "completed" {
+			t.Status = StatusDone
+			t.Completed = parseTaskwarriorTime(tw.End)
+		}
+		for _, a := range tw.Annotations {
+			t.Annotations = append(t.Annotations, Annotation{
+				Entry:       parseTaskwarriorTime(a.Entry),
+				Description: a.Description,
+			})
+		}
+		tasks = append(tasks, t)
+	}
+	return tasks, nil
+}
+
+func taskwarriorPriority(p Priority) string {
+	switch p {
+	case PriorityHigh:
+		return "H"
+	case PriorityMedium:
+		return "M"
+	case PriorityLow:
+		return "L"
+	default:
+		return ""
+	}
+}
+
+func priorityFromTaskwarrior(s string) Priority {
+	switch s {
+	case "H":
+		return PriorityHigh
+	case "M":
+		return PriorityMedium
+	case "L":
+		return PriorityLow
+	default:
+		return PriorityNone
+	}
+}
+
+func formatTaskwarriorTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(taskwarriorTimeLayout)
+}
+
+func parseTaskwarriorTime(s string) time.Time {
+	if s == "" {
+		return time.Time{}
+	}
+	t, err := time.Parse(taskwarriorTimeLayout, s)
+	if err != nil {
+		// Taskwarrior 3 は RFC 3339 形式の場合がある
+		if t, err = time.Parse(time.RFC3339, s); err != nil {
+			return time.Time{}
+		}
+	}
+	return t.Local()
+}
@@ -0,0 +1,197 @@
+package task
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWriteTaskwarrior(t *testing.T) {
+	tasks := []*Task{
+		{
+			ID:          3,
+			Description: "レポート提出 <draft>",
+			Priority:    PriorityHigh,
+			Project:     "仕事",
+			Tags:        []string{"office"},
+			NoteID:      "週次MTG.md",
+			DueDate:     time.Date(2026, 1, 20, 23, 59, 59, 0, time.UTC),
+			Created:     time.Date(2026, 1, 10, 9, 0, 0, 0, time.UTC),
+			Annotations: []Annotation{{Entry: time.Date(2026, 1, 11, 0, 0, 0, 0, time.UTC), Description: "メモ"}},
+		},
+		{
+			ID:          4,
+			UUID:        "aaaaaaaa-bbbb-4ccc-8ddd-eeeeeeeeeeee",
+			Description: "買い物",
+			Priority:    PriorityLow,
+			Status:      StatusDone,
+			Completed:   time.Date(2026, 1, 11, 18, 0, 0, 0, time.UTC),
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteTaskwarrior(&buf, tasks); err != nil {
+		t.Fatalf("WriteTaskwarrior() error = %v", err)
+	}
+
+	var got []map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("output is not a JSON array: %v\n%s", err, buf.String())
+	}
+	if len(got) != 2 {
+		t.Fatalf("got %d items, want 2", len(got))
+	}
+
+	first := got[0]
+	for key, want := range map[string]any{
+		"id":           float64(3),
+		"uuid":         tasks[0].UUID,
+		"description":  "レポート提出 <draft>",
+		"status":       "pending",
+		"priority":     "H",
+		"project":      "仕事",
+		"due":          "20260120T235959Z",
+		"entry":        "20260110T090000Z",
+		"notecli_note": "週次MTG.md",
+	} {
+		if first[key] != want {
+			t.Errorf("item[0][%q] = %v, want %v", key, first[key], want)
+		}
+	}
+	if anns, ok := first["annotations"].([]any); !ok || len(anns) != 1 {
+		t.Errorf("item[0] annotations = %v", first["annotations"])
+	}
+
+	second := got[1]
+	if second["uuid"] != tasks[1].UUID || second["status"] != "completed" || second["end"] != "20260111T180000Z" {
+		t.Errorf("item[1] = %v", second)
+	}
+	if _, ok := second["id"]; ok {
+		t.Error("completed task should not have a working id")
+	}
+}
+
+func TestReadTaskwarrior(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{
+			name: "array",
+			input: `[
+{"id":1,"uuid":"u-1","description":"外部タスク","status":"pending","entry":"20260101T100000Z","due":"2026-02-01T15:00:00Z","priority":"M","tags":["a","b"],"annotations":[{"entry":"20260102T100000Z","description":"注記"}],"urgency":4.2},
+{"uuid":"u-2","description":"消した","status":"deleted"},
+{"uuid":"u-3","description":"済み","status":"completed","end":"20260103T100000Z"}
+]`,
+		},
+		{
+			name: "one object per line",
+			input: `{"id":1,"uuid":"u-1","description":"外部タスク","status":"pending","entry":"20260101T100000Z","due":"2026-02-01T15:00:00Z","priority":"M","tags":["a","b"],"annotations":[{"entry":"20260102T100000Z","description":"注記"}]}
+{"uuid":"u-2","description":"消した","status":"deleted"}
+{"uuid":"u-3","description":"済み","status":"completed","end":"20260103T100000Z"}
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tasks, err := ReadTaskwarrior(strings.NewReader(tt.input))
+			if err != nil {
+				t.Fatalf("ReadTaskwarrior() error = %v", err)
+			}
+			if len(tasks) != 2 {
+				t.Fatalf("ReadTaskwarrior() returned %d tasks, want 2 (deleted skipped)", len(tasks))
+			}
+
+			t1 := tasks[0]
+			if t1.ID != 0 || t1.UUID != "u-1" || t1.Description != "外部タスク" || t1.Priority != PriorityMedium {
+				t.Errorf("task 1 = %+v", t1)
+			}
+			if !t1.DueDate.Equal(time.Date(2026, 2, 1, 15, 0, 0, 0, time.UTC)) {
+				t.Errorf("task 1 DueDate = %v", t1.DueDate)
+			}
+			if len(t1.Tags) != 2 || len(t1.Annotations) != 1 || t1.Annotations[0].Description != "注記" {
+				t.Errorf("task 1 tags/annotations = %v/%v", t1.Tags, t1.Annotations)
+			}
+
+			t2 := tasks[1]
+			if !t2.IsDone() || t2.Completed.IsZero() || t2.Priority != PriorityNone {
+				t.Errorf("task 2 = %+v", t2)
+			}
+		})
+	}
+}
+
+func TestReadTaskwarriorInvalid(t *testing.T) {
+	if _, err := ReadTaskwarrior(strings.NewReader(`[{"uuid":`)); err == nil {
+		t.Error("ReadTaskwarrior() should fail on broken JSON")
+	}
+	tasks, err := ReadTaskwarrior(strings.NewReader("  \n"))
+	if err != nil || len(tasks) != 0 {
+		t.Errorf("ReadTaskwarrior(empty) = %v, %v", tasks, err)
+	}
+}
+
+func TestEnsureUUID(t *testing.T) {
+	uuidPattern := regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+
+	a := &Task{ID: 1}
+	uuid := a.EnsureUUID()
+	if !uuidPattern.MatchString(uuid) {
+		t.Errorf("EnsureUUID() = %q, not a v4 UUID", uuid)
+	}
+	if a.UUID != uuid || a.EnsureUUID() != uuid {
+		t.Error("EnsureUUID() should store and keep the UUID")
+	}
+	// 同じIDでも別のストアのタスクとは衝突しない
+	if uuid == (&Task{ID: 1}).EnsureUUID() {
+		t.Error("EnsureUUID() should not be derived from the ID")
+	}
+	if NewTask(1, "a", PriorityLow).UUID == "" {
+		t.Error("NewTask() should assign a UUID")
+	}
+
+	b := &Task{ID: 1, UUID: "explicit"}
+	if b.EnsureUUID() != "explicit" {
+		t.Errorf("EnsureUUID() = %q, want assigned UUID", b.EnsureUUID())
+	}
+}
+
+func TestManagerImportTaskwarrior(t *testing.T) {
+	manager, tmpDir := setupTestManager(t)
+	defer os.RemoveAll(tmpDir)
+
+	manager.Add("一つ目", PriorityLow, "", time.Time{})
+	manager.Add("二つ目", PriorityLow, "", time.Time{})
+
+	var buf bytes.Buffer
+	WriteTaskwarrior(&buf, manager.List(true))
+
+	// Taskwarrior 側で説明文と優先度を変更した想定
+	edited := strings.Replace(buf.String(), "二つ目", "二つ目 (編集)", 1)
+	edited = strings.Replace(edited, `"priority": "L"`, `"priority": "H"`, 1)
+
+	tasks, err := ReadTaskwarrior(strings.NewReader(edited))
+	if err != nil {
+		t.Fatalf("ReadTaskwarrior() error = %v", err)
+	}
+	added, updated, err := manager.Import(tasks)
+	if err != nil {
+		t.Fatalf("Import() error = %v", err)
+	}
+	if added != 0 || updated != 2 {
+		t.Errorf("Import() = %d added, %d updated, want 0, 2", added, updated)
+	}
+
+	t2, _ := manager.Get(2)
+	if t2.Description != "二つ目 (編集)" || t2.UUID == "" {
+		t.Errorf("matched by UUID: got %+v", t2)
+	}
+	if len(manager.List(true)) != 2 {
+		t.Errorf("List(true) returned %d tasks, want 2", len(manager.List(true)))
+	}
+}
@@ -0,0 +1,237 @@
+package task
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// todo.txt のキー名
+const (
+	todoTxtKeyDue      = "due"
+	todoTxtKeyNote     = "note"
+	todoTxtKeyID       = "nid"
+	todoTxtKeyUUID     = "nuuid"
+	todoTxtKeyPriority = "pri"
+	todoTxtDateLayout  = "2006-01-02"
+)
+
+var todoTxtDatePattern = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}$`)
+
+// FormatTodoTxt formats a task as a todo.txt line.
+// Priority maps to (A)/(B)/(C), tags to @contexts, the project to +project,
+// and due date, linked note, task ID and UUID to key:value pairs.
+func FormatTodoTxt(t *Task) string {
+	var parts []string
+
+	if t.IsDone() {
+		parts = append(parts, "x")
+		if !t.Completed.IsZero() {
+			parts = append(parts, t.Completed.Format(todoTxtDateLayout))
+		}
+	} else if letter := priorityLetter(t.Priority); letter != "" {
+		parts = append(parts, "("+letter+")")
+	}
+
+	if !t.Created.IsZero() {
+		parts = append(parts, t.Created.Format(todoTxtDateLayout))
+	}
+
+	parts = append(parts, t.Description)
+
+	if t.Project != "" {
+		parts = append(parts, "+"+escapeTodoTxt(t.Project))
+	}
+	for _, tag := range t.Tags {
+		parts = append(parts, "@"+escapeTodoTxt(tag))
+	}
+	if t.HasDueDate() {
+		parts = append(parts, todoTxtKeyDue+":"+t.DueDate.Format(todoTxtDateLayout))
+	}
+	if t.HasNote() {
+		parts = append(parts, todoTxtKeyNote+":"+escapeTodoTxt(t.NoteID))
+	}
+	// 完了タスクは todo.txt の慣習に従い優先度を pri: に退避する
+	if t.IsDone() {
+		if letter := priorityLetter(t.Priority); letter != "" {
+			parts = append(parts, todoTxtKeyPriority+":"+letter)
+		}
+	}
+	if t.ID > 0 {
+		parts = append(parts, todoTxtKeyID+":"+strconv.Itoa(t.ID))
+	}
+	if t.UUID != "" {
+		parts = append(parts, todoTxtKeyUUID+":"+t.UUID)
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// ParseTodoTxt parses a todo.txt line into a task. The returned task has
+// ID and UUID set only when the line carries nid: and nuuid: keys.
+func ParseTodoTxt(line string) (*Task, error) {
+	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("空の行です")
+	}
+
+	t := &Task{Status: StatusPending}
+	i := 0
+
+	if fields[i] == "x" {
+		t.Status = StatusDone
+		i++
+		if i < len(fields) && todoTxtDatePattern.MatchString(fields[i]) {
+			t.Completed = parseTodoTxtDate(fields[i], 0, 0, 0)
+			i++
+		}
+	}
+
+	if i < len(fields) && len(fields[i]) == 3 && fields[i][0] == '(' && fields[i][2] == ')' {
+		t.Priority = priorityFromLetter(fields[i][1:2])
+		i++
+	}
+
+	if i < len(fields) && todoTxtDatePattern.MatchString(fields[i]) {
+		t.Created = parseTodoTxtDate(fields[i], 0, 0, 0)
+		i++
+	}
+
+	var desc []string
+	for _, f := range fields[i:] {
+		switch {
+		case len(f) > 1 && f[0] == '+':
+			if t.Project == "" {
+				t.Project = unescapeTodoTxt(f[1:])
+			} else {
+				t.Tags = append(t.Tags, unescapeTodoTxt(f[1:]))
+			}
+		case len(f) > 1 && f[0] == '@':
+			t.Tags = append(t.Tags, unescapeTodoTxt(f[1:]))
+		case isTodoTxtKeyValue(f):
+			key, value, _ := strings.Cut(f, ":")
+			if !applyTodoTxtKey(t, key, value) {
+				desc = append(desc, f)
+			}
+		default:
+			desc = append(desc, f)
+		}
+	}
+
+	t.Description = strings.Join(desc, " ")
+	if t.Description == "" {
+		return nil, fmt.Errorf("タスクの説明がありません: %s", line)
+	}
+	return t, nil
+}
+
+// ReadTodoTxt reads tasks from todo.txt content, skipping blank lines.
+func ReadTodoTxt(r io.Reader) ([]*Task, error) {
+	var tasks []*Task
+	scanner := bufio.NewScanner(r)
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		t, err := ParseTodoTxt(line)
+		if err != nil {
+			return nil, fmt.Errorf("%d行目: %w", lineNo, err)
+		}
+		tasks = append(tasks, t)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("todo.txt の読み込みに失敗: %w", err)
+	}
+	return tasks, nil
+}
+
+// WriteTodoTxt writes tasks as todo.txt lines.
+func WriteTodoTxt(w io.Writer, tasks []*Task) error {
+	for _, t := range tasks {
+		if _, err := fmt.Fprintln(w, FormatTodoTxt(t)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func applyTodoTxtKey(t *Task, key, value string) bool {
+	switch key {
+	case todoTxtKeyDue:
+		if !todoTxtDatePattern.MatchString(value) {
+			return false
+		}
+		t.DueDate = parseTodoTxtDate(value, 23, 59, 59)
+	case todoTxtKeyNote:
+		t.NoteID = unescapeTodoTxt(value)
+	case todoTxtKeyID:
+		id, err := strconv.Atoi(value)
+		if err != nil {
+			return false
+		}
+		t.ID = id
+	case todoTxtKeyUUID:
+		t.UUID = value
+	case todoTxtKeyPriority:
+		t.Priority = priorityFromLetter(value)
+	default:
+		return false
+	}
+	return true
+}
+
+// isTodoTxtKeyValue reports whether f is a key:value token (not a URL).
+func isTodoTxtKeyValue(f string) bool {
+	key, value, ok := strings.Cut(f, ":")
+	return ok && key != "" && value != "" && !strings.HasPrefix(value, "//")
+}
+
+func parseTodoTxtDate(s string, hour, minute, sec int) time.Time {
+	d, err := time.ParseInLocation(todoTxtDateLayout, s, time.Local)
+	if err != nil {
+		return time.Time{}
+	}
+	return time.Date(d.Year(), d.Month(), d.Day(), hour, minute, sec, 0, time.Local)
+}
+
+func priorityLetter(p Priority) string {
+	switch p {
+	case PriorityHigh:
+		return "A"
+	case PriorityMedium:
+		return "B"
+	case PriorityLow:
+		return "C"
+	default:
+		return ""
+	}
+}
+
+func priorityFromLetter(s string) Priority {
+	switch strings.ToUpper(s) {
+	case "A":
+		return PriorityHigh
+	case "B":
+		return PriorityMedium
+	case "":
+		return PriorityNone
+	default:
+		return PriorityLow
+	}
+}
+
+// todo.txt はスペース区切りのため、値に含まれるスペースは %20 で表す
+func escapeTodoTxt(s string) string {
+	return strings.ReplaceAll(s, " ", "%20")
+}
+
+func unescapeTodoTxt(s string) string {
+	return strings.ReplaceAll(s, "%20", " ")
+}
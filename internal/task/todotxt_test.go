@@ -0,0 +1,293 @@
+package task
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFormatTodoTxt(t *testing.T) {
+	created := time.Date(2026, 1, 10, 9, 0, 0, 0, time.Local)
+
+	pending := &Task{
+		ID:          3,
+		Description: "レポート提出",
+		Priority:    PriorityHigh,
+		Project:     "仕事",
+		Tags:        []string{"office"},
+		NoteID:      "週次 MTG.md",
+		DueDate:     time.Date(2026, 1, 20, 23, 59, 59, 0, time.Local),
+		Created:     created,
+		UUID:        "0b6c2a5e-3d1f-4c4e-9a7b-2f8e1d0c9b11",
+	}
+	want := "(A) 2026-01-10 レポート提出 +仕事 @office due:2026-01-20 note:週次%20MTG.md nid:3 nuuid:0b6c2a5e-3d1f-4c4e-9a7b-2f8e1d0c9b11"
+	if got := FormatTodoTxt(pending); got != want {
+		t.Errorf("FormatTodoTxt(pending) =\n  %q\nwant\n  %q", got, want)
+	}
+
+	done := &Task{
+		ID:          4,
+		Description: "買い物",
+		Priority:    PriorityMedium,
+		Status:      StatusDone,
+		Created:     created,
+		Completed:   time.Date(2026, 1, 11, 18, 0, 0, 0, time.Local),
+	}
+	want = "x 2026-01-11 2026-01-10 買い物 pri:B nid:4"
+	if got := FormatTodoTxt(done); got != want {
+		t.Errorf("FormatTodoTxt(done) =\n  %q\nwant\n  %q", got, want)
+	}
+}
+
+func TestParseTodoTxt(t *testing.T) {
+	tests := []struct {
+		name  string
+		line  string
+		check func(t *testing.T, got *Task)
+	}{
+		{
+			name: "full pending",
+			line: "(A) 2026-01-10 レポート提出 +仕事 @office @home due:2026-01-20 note:週次%20MTG.md nid:3 nuuid:u-3",
+			check: func(t *testing.T, got *Task) {
+				if got.Description != "レポート提出" || got.Priority != PriorityHigh || got.ID != 3 || got.UUID != "u-3" {
+					t.Errorf("got %+v", got)
+				}
+				if got.Project != "仕事" || len(got.Tags) != 2 || got.Tags[1] != "home" {
+					t.Errorf("Project/Tags = %q/%v", got.Project, got.Tags)
+				}
+				if got.NoteID != "週次 MTG.md" {
+					t.Errorf("NoteID = %q", got.NoteID)
+				}
+				wantDue := time.Date(2026, 1, 20, 23, 59, 59, 0, time.Local)
+				if !got.DueDate.Equal(wantDue) {
+					t.Errorf("DueDate = %v, want %v", got.DueDate, wantDue)
+				}
+				if got.Created.Day() != 10 {
+					t.Errorf("Created = %v", got.Created)
+				}
+			},
+		},
+		{
+			name: "completed with pri",
+			line: "x 2026-01-11 2026-01-10 買い物 pri:B",
+			check: func(t *testing.T, got *Task) {
+				if !got.IsDone() || got.Completed.Day() != 11 || got.Created.Day() != 10 {
+					t.Errorf("got %+v", got)
+				}
+				if got.Priority != PriorityMedium {
+					t.Errorf("Priority = %v, want %v", got.Priority, PriorityMedium)
+				}
+			},
+		},
+		{
+			name: "plain with url and unknown key",
+			line: "see https://example.com and foo:bar",
+			check: func(t *testing.T, got *Task) {
+				if got.Description != "see https://example.com and foo:bar" {
+					t.Errorf("Description = %q", got.Description)
+				}
+				if got.Priority != PriorityNone || got.IsDone() {
+					t.Errorf("got %+v", got)
+				}
+			},
+		},
+		{
+			name: "low letters",
+			line: "(D) あとで",
+			check: func(t *testing.T, got *Task) {
+				if got.Priority != PriorityLow {
+					t.Errorf("Priority = %v, want %v", got.Priority, PriorityLow)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseTodoTxt(tt.line)
+			if err != nil {
+				t.Fatalf("ParseTodoTxt() error = %v", err)
+			}
+			tt.check(t, got)
+		})
+	}
+}
+
+func TestParseTodoTxtErrors(t *testing.T) {
+	for _, line := range []string{"", "   ", "(A) 2026-01-01 due:2026-01-02"} {
+		if _, err := ParseTodoTxt(line); err == nil {
+			t.Errorf("ParseTodoTxt(%q) should fail", line)
+		}
+	}
+}
+
+func TestTodoTxtRoundTrip(t *testing.T) {
+	orig := &Task{
+		ID:          7,
+		Description: "往復テスト",
+		Priority:    PriorityLow,
+		Project:     "p",
+		Tags:        []string{"a", "b"},
+		DueDate:     time.Date(2026, 2, 1, 23, 59, 59, 0, time.Local),
+		Created:     time.Date(2026, 1, 1, 0, 0, 0, 0, time.Local),
+		UUID:        "u-7",
+	}
+
+	var buf bytes.Buffer
+	if err := WriteTodoTxt(&buf, []*Task{orig}); err != nil {
+		t.Fatalf("WriteTodoTxt() error = %v", err)
+	}
+	tasks, err := ReadTodoTxt(strings.NewReader("\n" + buf.String() + "\n"))
+	if err != nil {
+		t.Fatalf("ReadTodoTxt() error = %v", err)
+	}
+	if len(tasks) != 1 {
+		t.Fatalf("ReadTodoTxt() returned %d tasks, want 1", len(tasks))
+	}
+
+	got := tasks[0]
+	if got.ID != orig.ID || got.UUID != orig.UUID || got.Description != orig.Description || got.Priority != orig.Priority ||
+		got.Project != orig.Project || len(got.Tags) != 2 || !got.DueDate.Equal(orig.DueDate) || !got.Created.Equal(orig.Created) {
+		t.Errorf("round trip mismatch:\n got  %+v\n want %+v", got, orig)
+	}
+}
+
+func TestManagerImport(t *testing.T) {
+	manager, tmpDir := setupTestManager(t)
+	defer os.RemoveAll(tmpDir)
+
+	manager.Add("既存タスク", PriorityLow, "", time.Time{})
+	byUUID := manager.Add("UUID一致", PriorityLow, "", time.Time{})
+
+	imported, err := ReadTodoTxt(strings.NewReader(
+		"(A) 既存タスク\n" +
+			"x 2026-01-05 名前変更 nid:9 nuuid:" + byUUID.UUID + "\n" +
+			"(B) 新規タスク +proj\n"))
+	if err != nil {
+		t.Fatalf("ReadTodoTxt() error = %v", err)
+	}
+
+	added, updated, err := manager.Import(imported)
+	if err != nil {
+		t.Fatalf("Import() error = %v", err)
+	}
+	if added != 1 || updated != 2 {
+		t.Errorf("Import() = %d added, %d updated, want 1, 2", added, updated)
+	}
+
+	t1, _ := manager.Get(1)
+	if t1.Priority != PriorityHigh {
+		t.Errorf("Matched by description: Priority = %v, want %v", t1.Priority, PriorityHigh)
+	}
+	t2, _ := manager.Get(2)
+	if t2.Description != "名前変更" || !t2.IsDone() {
+		t.Errorf("Matched by UUID: got %+v", t2)
+	}
+	t3, err := manager.Get(3)
+	if err != nil || t3.Description != "新規タスク" || t3.Created.IsZero() {
+		t.Errorf("New task: got %+v, %v", t3, err)
+	}
+
+	// Re-importing must not duplicate
+	added, updated, err = manager.Import(imported)
+	if err != nil {
+		t.Fatalf("Import() error = %v", err)
+	}
+	if added != 0 || updated != 3 {
+		t.Errorf("Re-import = %d added, %d updated, want 0, 3", added, updated)
+	}
+	if len(manager.List(true)) != 3 {
+		t.Errorf("List(true) returned %d tasks, want 3", len(manager.List(true)))
+	}
+}
+
+func TestManagerImportArchived(t *testing.T) {
+	manager, tmpDir := setupTestManager(t)
+	defer os.RemoveAll(tmpDir)
+
+	manager.Add("完了済み", PriorityLow, "", time.Time{})
+	manager.Add("進行中", PriorityLow, "", time.Time{})
+	manager.Done(1)
+
+	var buf strings.Builder
+	if err := WriteTodoTxt(&buf, manager.List(true)); err != nil {
+		t.Fatalf("WriteTodoTxt() error = %v", err)
+	}
+	if _, err := manager.Archive(0); err != nil {
+		t.Fatalf("Archive() error = %v", err)
+	}
+
+	imported, err := ReadTodoTxt(strings.NewReader(buf.String()))
+	if err != nil {
+		t.Fatalf("ReadTodoTxt() error = %v", err)
+	}
+	// 再インポートしてもアーカイブ済みのタスクは復活しない
+	for i := 0; i < 2; i++ {
+		added, updated, err := manager.Import(imported)
+		if err != nil {
+			t.Fatalf("Import() error = %v", err)
+		}
+		if added != 0 || updated != 1 {
+			t.Errorf("Import #%d = %d added, %d updated, want 0, 1", i+1, added, updated)
+		}
+	}
+
+	tasks := manager.List(true)
+	if len(tasks) != 1 || tasks[0].Description != "進行中" {
+		t.Errorf("Archived task was imported again: %+v", tasks)
+	}
+}
+
+func TestManagerImportRecurring(t *testing.T) {
+	manager, tmpDir := setupTestManager(t)
+	defer os.RemoveAll(tmpDir)
+
+	manager.Add("週報を書く", PriorityLow, "", time.Time{})
+	manager.Done(1)
+	manager.Archive(0)
+
+	// UUID のない行は、説明文がアーカイブ済みのタスクと同じでも新しいタスクになる
+	imported, err := ReadTodoTxt(strings.NewReader("週報を書く\n"))
+	if err != nil {
+		t.Fatalf("ReadTodoTxt() error = %v", err)
+	}
+	added, _, err := manager.Import(imported)
+	if err != nil {
+		t.Fatalf("Import() error = %v", err)
+	}
+	if added != 1 || len(manager.List(false)) != 1 {
+		t.Errorf("Import() added %d, pending %d, want 1, 1", added, len(manager.List(false)))
+	}
+}
+
+func TestManagerImportIgnoresID(t *testing.T) {
+	manager, tmpDir := setupTestManager(t)
+	defer os.RemoveAll(tmpDir)
+
+	manager.Add("別のタスク", PriorityLow, "", time.Time{})
+	manager.Add("対象タスク", PriorityLow, "", time.Time{})
+
+	imported, err := ReadTodoTxt(strings.NewReader(
+		"(A) 対象タスク nid:1\n" +
+			"他のストアのタスク nid:1 nuuid:0b6c2a5e-3d1f-4c4e-9a7b-2f8e1d0c9b11\n"))
+	if err != nil {
+		t.Fatalf("ReadTodoTxt() error = %v", err)
+	}
+	if _, _, err := manager.Import(imported); err != nil {
+		t.Fatalf("Import() error = %v", err)
+	}
+
+	t1, _ := manager.Get(1)
+	t2, _ := manager.Get(2)
+	if t1.Description != "別のタスク" || t1.Priority != PriorityLow {
+		t.Errorf("Unrelated task was overwritten: %+v", t1)
+	}
+	if t2.Priority != PriorityHigh {
+		t.Errorf("Matched by description: Priority = %v, want %v", t2.Priority, PriorityHigh)
+	}
+	if t3, err := manager.Get(3); err != nil || t3.Description != "他のストアのタスク" {
+		t.Errorf("Foreign task should be added as new: %+v, %v", t3, err)
+	}
+}
@@ -5,10 +5,12 @@ import (
 	"strings"
 	"time"
 
+	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/intiramisu/note-cli/internal/config"
+	"github.com/intiramisu/note-cli/internal/note"
 	"github.com/intiramisu/note-cli/internal/util"
 	"github.com/mattn/go-runewidth"
 )
@@ -24,6 +26,8 @@ type mode int
 const (
 	modeNormal mode = iota
 	modeAdd
+	modeDue    // 既存タスクの期限入力
+	modeFilter // 絞り込みクエリの入力
 )
 
 type sectionInfo struct {
@@ -31,11 +35,14 @@ type sectionInfo struct {
 	color    string
 	priority Priority
 	isDone   bool
+	colored  bool // true: タイトルを color で描画 (カンバンの任意列)
 	tasks    []*Task
 }
 
 type Model struct {
 	manager     *Manager
+	storage     *note.Storage // メモへの紐づけに使う (nil なら無効)
+	board       *Board
 	sections    []sectionInfo
 	sectionIdx  int
 	taskIdx     int
@@ -46,15 +53,49 @@ type Model struct {
 	dueInput    textinput.Model
 	addDue      time.Time
 	sortByDue   bool // true: 期限順, false: 優先度順
+	agendaView  bool // true: アジェンダ表示 (期限で区分)
 	quitting    bool
 	width       int
 	height      int
+	keys        keyMap
+	embedded    bool // 統合TUIから開かれている (終了キーで ClosedMsg を送る)
+	status      string
+
+	// 複数選択・一括操作
+	sel        Selection
+	confirmIDs []int    // 削除確認中のタスク
+	dueIDs     []int    // 期限入力中のタスク
+	linkIDs    []int    // メモ選択中のタスク
+	linkNotes  []string // メモ選択の候補 (先頭は紐づけ解除)
+
+	// 絞り込み
+	query       *Query // nil なら全件表示
+	viewName    string // task_views から選んだ場合のビュー名
+	filterInput textinput.Model
+	viewNames   []string // ビュー選択の候補
+
+	// ヘルプ・コマンドパレット
+	showHelp    bool
+	help        util.HelpView
+	paletteOpen bool
+	palette     util.Palette
+	actions     []paletteAction
 }
 
-func NewModel(manager *Manager) Model {
+// ClosedMsg is sent instead of quitting when an embedded board is closed.
+type ClosedMsg struct{}
+
+// NewModel creates the task board. storage is used to link tasks to notes
+// and may be nil.
+func NewModel(manager *Manager, storage *note.Storage) (Model, error) {
 	initStyles()
 	cfg := config.Global
 
+	keys, err := newKeyMap(cfg.Keys.Task)
+	if err != nil {
+		return Model{}, fmt.Errorf("keys.task: %w", err)
+	}
+
 	ti := textinput.New()
 	ti.CharLimit = cfg.Display.TaskCharLimit
 	ti.Width = cfg.Display.InputWidth
@@ -63,17 +104,36 @@ func NewModel(manager *Manager) Model {
 	di.CharLimit = 20
 	di.Width = 30
 
+	fi := textinput.New()
+	fi.Width = cfg.Display.InputWidth
+
+	if storage == nil {
+		keys.LinkNote.SetEnabled(false)
+	}
+
 	m := Model{
 		manager:     manager,
+		storage:     storage,
 		textInput:   ti,
 		dueInput:    di,
+		filterInput: fi,
 		addPriority: PriorityMedium,
 		width:       120,
 		height:      24,
+		keys:        keys,
+		palette:     util.NewPalette(cfg.Display.InputWidth, keys.paletteKeys()),
 	}
 	m.refreshTasks()
 	m.findFirstTask()
-	return m
+	return m, nil
+}
+
+// NewEmbeddedModel creates a board for use inside another TUI; quitting it
+// sends ClosedMsg instead of ending the program.
+func NewEmbeddedModel(manager *Manager, storage *note.Storage) (Model, error) {
+	m, err := NewModel(manager, storage)
+	m.embedded = true
+	return m, err
 }
 
 func (m *Model) findFirstTask() {
@@ -97,16 +157,42 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
+		m.help.SetSize(m.width, m.height)
+		return m, nil
+
+	case tea.MouseMsg:
+		return m.updateMouse(msg)
+
+	case util.FilesChangedMsg:
+		m.reload()
 		return m, nil
 
 	case tea.KeyMsg:
-		if m.mode == modeAdd {
+		m.status = ""
+		if m.showHelp {
+			return m.updateHelp(msg)
+		}
+		if m.paletteOpen {
+			return m.updatePalette(msg)
+		}
+		if m.confirmIDs != nil {
+			return m.updateConfirmDelete(msg)
+		}
+		switch m.mode {
+		case modeAdd:
 			return m.updateAddMode(msg)
+		case modeDue:
+			return m.updateDueMode(msg)
+		case modeFilter:
+			return m.updateFilterMode(msg)
 		}
 		return m.updateNormalMode(msg)
 	}
 
 	// Forward other messages (cursor blink etc.) to active text input
+	if m.paletteOpen {
+		return m.updatePalette(msg)
+	}
 	if m.mode == modeAdd {
 		var cmd tea.Cmd
 		if m.settingDue {
@@ -116,58 +202,160 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		return m, cmd
 	}
+	if m.mode == modeDue {
+		var cmd tea.Cmd
+		m.dueInput, cmd = m.dueInput.Update(msg)
+		return m, cmd
+	}
+	if m.mode == modeFilter {
+		var cmd tea.Cmd
+		m.filterInput, cmd = m.filterInput.Update(msg)
+		return m, cmd
+	}
 
 	return m, nil
 }
 
 func (m Model) updateNormalMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
-	case "q", "ctrl+c":
-		m.quitting = true
-		return m, tea.Quit
+	k := m.keys
+
+	switch {
+	case key.Matches(msg, k.Quit):
+		return m, m.quit()
 
-	case "up", "k":
+	case key.Matches(msg, k.Help):
+		m.openHelp()
+
+	case key.Matches(msg, k.Palette):
+		return m, m.openPalette()
+
+	case key.Matches(msg, k.Up):
 		m.moveUp()
 
-	case "down", "j":
+	case key.Matches(msg, k.Down):
 		m.moveDown()
 
-	case "left", "h":
-		m.moveLeft()
+	case key.Matches(msg, k.Left):
+		m.keepVisual(m.moveLeft)
 
-	case "right", "l":
-		m.moveRight()
+	case key.Matches(msg, k.Right):
+		m.keepVisual(m.moveRight)
 
-	case "enter", " ":
-		if task := m.currentTask(); task != nil {
-			taskID := task.ID
-			m.manager.Toggle(taskID)
-			m.refreshTasks()
-			m.moveCursorToTask(taskID)
-		}
+	case key.Matches(msg, k.MoveLeft):
+		m.moveTaskToColumn(-1)
 
-	case "i":
-		m.mode = modeAdd
-		m.addPriority = PriorityMedium
-		m.textInput.Focus()
-		return m, textinput.Blink
+	case key.Matches(msg, k.MoveRight):
+		m.moveTaskToColumn(1)
 
-	case "d", "x":
-		if task := m.currentTask(); task != nil {
-			m.manager.Delete(task.ID)
-			m.refreshTasks()
-			m.adjustCursor()
-		}
+	case key.Matches(msg, k.MoveUp):
+		m.reorderTask(-1)
 
-	case "s":
-		m.sortByDue = !m.sortByDue
-		m.refreshTasks()
-		m.findFirstTask()
+	case key.Matches(msg, k.MoveDown):
+		m.reorderTask(1)
+
+	case key.Matches(msg, k.Toggle):
+		m.toggleTargets()
+
+	case key.Matches(msg, k.Add):
+		return m, m.startAdd()
+
+	case key.Matches(msg, k.Delete):
+		m.deleteTargets()
+
+	case key.Matches(msg, k.Mark):
+		m.toggleMark()
+
+	case key.Matches(msg, k.Visual):
+		m.toggleVisual()
+
+	case key.Matches(msg, k.Unmark) && m.sel.Active():
+		m.sel.Clear()
+
+	case key.Matches(msg, k.ClearFilter) && m.query != nil:
+		m.setQuery(nil, "")
+
+	case key.Matches(msg, k.Filter):
+		return m, m.startFilter()
+
+	case key.Matches(msg, k.Views):
+		return m, m.startViews()
+
+	case key.Matches(msg, k.PriorityHigh):
+		m.setPriority(PriorityHigh)
+
+	case key.Matches(msg, k.PriorityMedium):
+		m.setPriority(PriorityMedium)
+
+	case key.Matches(msg, k.PriorityLow):
+		m.setPriority(PriorityLow)
+
+	case key.Matches(msg, k.Due):
+		return m, m.startDue()
+
+	case key.Matches(msg, k.LinkNote):
+		return m, m.startLink()
+
+	case key.Matches(msg, k.Sort):
+		m.toggleSort()
+
+	case key.Matches(msg, k.Agenda):
+		m.toggleAgenda()
 	}
 
 	return m, nil
 }
 
+// reload picks up tasks changed by another process, keeping the cursor on
+// the same task when it still exists.
+func (m *Model) reload() {
+	var id int
+	if task := m.currentTask(); task != nil {
+		id = task.ID
+	}
+	if err := m.manager.Reload(); err != nil {
+		return
+	}
+	m.refreshTasks()
+	m.moveCursorToTask(id)
+	m.adjustCursor()
+}
+
+// quit ends the program, or hands control back when the board is embedded.
+func (m *Model) quit() tea.Cmd {
+	if m.embedded {
+		return func() tea.Msg { return ClosedMsg{} }
+	}
+	m.quitting = true
+	return tea.Quit
+}
+
+// toggleTargets toggles the marked tasks (or the current one) together.
+func (m *Model) toggleTargets() {
+	m.applyToTargets(m.manager.ToggleAll)
+}
+
+func (m *Model) startAdd() tea.Cmd {
+	m.mode = modeAdd
+	m.addPriority = PriorityMedium
+	m.textInput.Focus()
+	return textinput.Blink
+}
+
+func (m *Model) toggleSort() {
+	m.sel.EndVisual(m.sectionTasks(), m.taskIdx)
+	m.agendaView = false
+	m.sortByDue = !m.sortByDue
+	m.refreshTasks()
+	m.findFirstTask()
+}
+
+func (m *Model) toggleAgenda() {
+	m.sel.EndVisual(m.sectionTasks(), m.taskIdx)
+	m.agendaView = !m.agendaView
+	m.refreshTasks()
+	m.findFirstTask()
+}
+
 func (m *Model) moveUp() {
 	if m.taskIdx > 0 {
 		m.taskIdx--
@@ -204,6 +392,50 @@ func (m *Model) moveRight() {
 	}
 }
 
+// isBoardView reports whether the sections are kanban board columns.
+func (m *Model) isBoardView() bool {
+	return !m.agendaView && !m.sortByDue
+}
+
+// moveTaskToColumn moves the selected task to the neighbouring board column.
+func (m *Model) moveTaskToColumn(delta int) {
+	task := m.currentTask()
+	if task == nil || !m.isBoardView() {
+		return
+	}
+	target := m.sectionIdx + delta
+	if target < 0 || target >= len(m.board.Columns) {
+		return
+	}
+	taskID := task.ID
+	m.manager.MoveToColumn(taskID, m.board, target)
+	m.refreshTasks()
+	m.moveCursorToTask(taskID)
+	m.adjustCursor()
+}
+
+// reorderTask swaps the selected task with its neighbour and persists the column order.
+func (m *Model) reorderTask(delta int) {
+	task := m.currentTask()
+	if task == nil || !m.isBoardView() {
+		return
+	}
+	tasks := m.sections[m.sectionIdx].tasks
+	target := m.taskIdx + delta
+	if target < 0 || target >= len(tasks) {
+		return
+	}
+
+	ids := make([]int, len(tasks))
+	for i, t := range tasks {
+		ids[i] = t.ID
+	}
+	ids[m.taskIdx], ids[target] = ids[target], ids[m.taskIdx]
+	m.manager.Reorder(ids)
+	m.refreshTasks()
+	m.moveCursorToTask(task.ID)
+}
+
 func (m *Model) currentTask() *Task {
 	if m.sectionIdx >= len(m.sections) {
 		return nil
@@ -247,10 +479,12 @@ func (m *Model) adjustCursor() {
 }
 
 func (m Model) updateAddMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	k := m.keys
+
 	// 期限入力モード
 	if m.settingDue {
-		switch msg.String() {
-		case "enter":
+		switch {
+		case key.Matches(msg, k.Confirm):
 			if m.dueInput.Value() != "" {
 				m.addDue = util.ParseDueDateSimple(m.dueInput.Value())
 			}
@@ -268,7 +502,7 @@ func (m Model) updateAddMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			m.addDue = time.Time{}
 			return m, nil
 
-		case "esc":
+		case key.Matches(msg, k.Cancel):
 			m.settingDue = false
 			m.dueInput.Reset()
 			m.textInput.Focus()
@@ -281,8 +515,8 @@ func (m Model) updateAddMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	}
 
 	// タスク説明入力モード
-	switch msg.String() {
-	case "enter":
+	switch {
+	case key.Matches(msg, k.Confirm):
 		value := strings.TrimSpace(m.textInput.Value())
 		if value != "" {
 			newTask := m.manager.Add(value, m.addPriority, "", time.Time{})
@@ -295,22 +529,22 @@ func (m Model) updateAddMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		m.addDue = time.Time{}
 		return m, nil
 
-	case "esc":
+	case key.Matches(msg, k.Cancel):
 		m.textInput.Reset()
 		m.mode = modeNormal
 		m.addPriority = PriorityMedium
 		m.addDue = time.Time{}
 		return m, nil
 
-	case "tab":
+	case key.Matches(msg, k.NextPriority):
 		m.addPriority = CyclePriority(m.addPriority, false)
 		return m, nil
 
-	case "shift+tab":
+	case key.Matches(msg, k.PrevPriority):
 		m.addPriority = CyclePriority(m.addPriority, true)
 		return m, nil
 
-	case "ctrl+d":
+	case key.Matches(msg, k.SetDue):
 		if m.textInput.Value() != "" {
 			m.settingDue = true
 			m.textInput.Blur()
@@ -331,9 +565,17 @@ func (m *Model) refreshTasks() {
 	colors := cfg.Theme.Colors
 	sections := cfg.Theme.Sections
 
-	if m.sortByDue {
+	if m.agendaView {
+		// アジェンダ表示: 期限切れ/今日/明日/今週/それ以降/期限なし
+		groups := m.manager.Agenda(cfg.Display.AgendaDays)
+		m.sections = make([]sectionInfo, len(groups))
+		for i, g := range groups {
+			priority, color := agendaSectionStyle(g.Bucket, colors)
+			m.sections[i] = sectionInfo{name: g.Bucket.String(), color: color, priority: priority, tasks: m.visible(g.Tasks)}
+		}
+	} else if m.sortByDue {
 		// 期限順表示: 1セクションに全タスク
-		allTasks := m.manager.ListByDueDate(true)
+		allTasks := m.visible(m.manager.ListByDueDate(true))
 		var pending, done []*Task
 		for _, t := range allTasks {
 			if t.IsDone() {
@@ -347,33 +589,43 @@ func (m *Model) refreshTasks() {
 			{name: sections.Done, color: colors.Done, isDone: true, tasks: done},
 		}
 	} else {
-		// 優先度順表示: P1/P2/P3/Done
+		// カンバン表示: 設定の列定義 (デフォルトは P1/P2/P3/Done)
 		allTasks := m.manager.List(true)
-		m.sections = []sectionInfo{
-			{name: sections.P1, color: colors.PriorityHigh, priority: PriorityHigh, tasks: []*Task{}},
-			{name: sections.P2, color: colors.PriorityMedium, priority: PriorityMedium, tasks: []*Task{}},
-			{name: sections.P3, color: colors.PriorityLow, priority: PriorityLow, tasks: []*Task{}},
-			{name: sections.Done, color: colors.Done, isDone: true, tasks: []*Task{}},
-		}
-
-		for _, t := range allTasks {
-			idx := m.sectionIndexForTask(t)
-			m.sections[idx].tasks = append(m.sections[idx].tasks, t)
+		m.board = NewBoard(cfg.Board, cfg.Theme, allTasks)
+		grouped := m.board.Group(m.visible(allTasks))
+		m.sections = make([]sectionInfo, len(m.board.Columns))
+		for i, col := range m.board.Columns {
+			m.sections[i] = m.columnSection(col, grouped[i])
 		}
 	}
 }
 
-func (m *Model) sectionIndexForTask(t *Task) int {
-	if t.IsDone() {
-		return 3
+// columnSection converts a board column into a section, keeping the
+// priority-based title styles for the default columns.
+func (m *Model) columnSection(col Column, tasks []*Task) sectionInfo {
+	section := sectionInfo{name: col.Name, color: col.Color, tasks: tasks}
+	switch {
+	case col.Value == ColumnDone && (m.board.GroupBy == GroupByPriority || m.board.GroupBy == GroupByStatus):
+		section.isDone = true
+	case m.board.GroupBy == GroupByPriority:
+		section.priority = ParsePriority(col.Value)
+	default:
+		section.colored = true
 	}
-	switch t.Priority {
-	case PriorityHigh:
-		return 0
-	case PriorityMedium:
-		return 1
+	return section
+}
+
+// agendaSectionStyle maps an agenda bucket to a title priority style and border color.
+func agendaSectionStyle(b AgendaBucket, colors config.Colors) (Priority, string) {
+	switch b {
+	case BucketOverdue:
+		return PriorityHigh, colors.PriorityHigh
+	case BucketToday, BucketTomorrow:
+		return PriorityMedium, colors.PriorityMedium
+	case BucketThisWeek, BucketLater:
+		return PriorityLow, colors.PriorityLow
 	default:
-		return 2
+		return PriorityNone, colors.Empty
 	}
 }
 
@@ -392,20 +644,27 @@ func (m Model) renderSection(sectionIndex int, section sectionInfo, colWidth, co
 
 	for taskIndex, task := range section.tasks {
 		isSelected := sectionIndex == m.sectionIdx && taskIndex == m.taskIdx
-		line := m.renderTaskLine(task, colWidth, isSelected)
+		isMarked := m.sel.HasMark(task.ID)
+		if sectionIndex == m.sectionIdx {
+			isMarked = m.sel.IsMarked(section.tasks, taskIndex, m.taskIdx)
+		}
+		line := m.renderTaskLine(task, colWidth, isSelected, isMarked)
 		content.WriteString(line + "\n")
 	}
 
 	return style.Render(content.String())
 }
 
-func (m Model) renderTaskLine(task *Task, colWidth int, isSelected bool) string {
+func (m Model) renderTaskLine(task *Task, colWidth int, isSelected, isMarked bool) string {
 	cfg := config.Global
 	symbols := cfg.Theme.Symbols
 
 	cursor := symbols.CursorEmpty
-	if isSelected {
+	switch {
+	case isSelected:
 		cursor = symbols.Cursor
+	case isMarked:
+		cursor = symbols.Marked
 	}
 
 	checkbox := symbols.CheckboxEmpty
@@ -458,6 +717,9 @@ func (m Model) renderTaskLine(task *Task, colWidth int, isSelected bool) string
 	}
 
 	text := result.String()
+	if isMarked {
+		return styles.Marked.Render(text)
+	}
 	if isSelected {
 		return styles.Selected.Render(text)
 	}
@@ -472,6 +734,9 @@ func (m Model) sectionTitleStyle(section sectionInfo) lipgloss.Style {
 	if section.isDone {
 		return styles.DoneSection
 	}
+	if section.colored {
+		return lipgloss.NewStyle().Foreground(lipgloss.Color(section.color)).Bold(true)
+	}
 	switch section.priority {
 	case PriorityHigh:
 		return styles.PriorityHigh
@@ -508,12 +773,15 @@ func (m Model) View() string {
 	if m.quitting {
 		return ""
 	}
-
-	cfg := config.Global
-	symbols := cfg.Theme.Symbols
+	if m.showHelp {
+		return m.help.View(styles)
+	}
+	if m.paletteOpen {
+		return m.palette.View(styles, m.width, m.height)
+	}
 
 	var s strings.Builder
-	s.WriteString(styles.Title.Render(symbols.TaskIcon + " タスク管理"))
+	s.WriteString(m.renderTitle())
 	s.WriteString("\n\n")
 
 	colWidth, colHeight := m.calculateDimensions()
@@ -523,12 +791,33 @@ func (m Model) View() string {
 	if m.mode == modeAdd {
 		s.WriteString(m.renderAddInput())
 	}
+	if m.mode == modeFilter {
+		s.WriteString(m.renderFilterInput())
+	}
+	s.WriteString(m.renderSelection())
+	if m.status != "" {
+		s.WriteString("\n" + styles.Meta.Render(m.status))
+	}
 
 	s.WriteString("\n")
 	s.WriteString(styles.Help.Render(m.helpText()))
 	return s.String()
 }
 
+func (m Model) renderTitle() string {
+	title := config.Global.Theme.Symbols.TaskIcon + " タスク管理"
+	if m.agendaView {
+		title += " - アジェンダ"
+	}
+	switch {
+	case m.viewName != "":
+		title += " - ビュー: " + m.viewName
+	case m.query != nil:
+		title += " - 絞り込み: " + m.query.String()
+	}
+	return styles.Title.Render(title)
+}
+
 func (m Model) calculateDimensions() (width, height int) {
 	numSections := len(m.sections)
 	width = max((m.width-numSections*2)/numSections, 15)
@@ -554,21 +843,85 @@ func (m Model) renderAddInput() string {
 }
 
 func (m Model) helpText() string {
+	k := m.keys
+	if m.confirmIDs != nil {
+		return helpLine(helpItem("削除", k.Yes), helpItem("キャンセル", k.No))
+	}
+	if m.mode == modeDue || m.mode == modeFilter {
+		return helpLine(helpItem("確定", k.Confirm), helpItem("キャンセル", k.Cancel))
+	}
 	if m.mode == modeAdd {
 		if m.settingDue {
-			return "Enter:確定 Esc:戻る"
+			return helpLine(helpItem("確定", k.Confirm), helpItem("戻る", k.Cancel))
 		}
-		return "Enter:確定 Tab:優先度変更 Ctrl+D:期限設定 Esc:キャンセル"
+		return helpLine(
+			helpItem("確定", k.Confirm),
+			helpItem("優先度変更", k.NextPriority),
+			helpItem("期限設定", k.SetDue),
+			helpItem("キャンセル", k.Cancel),
+		)
+	}
+	sortLabel := "期限順"
+	if m.sortByDue && !m.agendaView {
+		sortLabel = "優先度順"
+	}
+	agendaLabel := "アジェンダ"
+	if m.agendaView {
+		agendaLabel = "通常表示"
+	}
+	entries := []helpEntry{
+		helpItem("追加", k.Add),
+		helpItem("削除", k.Delete),
+		helpItem("完了切替", k.Toggle),
+		helpItem(sortLabel, k.Sort),
+		helpItem(agendaLabel, k.Agenda),
+	}
+	entries = append(entries, helpItem("絞り込み", k.Filter), helpItem("ビュー", k.Views))
+	if m.query != nil && !m.sel.Active() {
+		entries = append(entries, helpItem("絞り込み解除", k.ClearFilter))
+	}
+	entries = append(entries, helpItem("印", k.Mark), helpItem("範囲選択", k.Visual))
+	if m.sel.Active() {
+		entries = append(entries,
+			helpItem("優先度", k.PriorityHigh, k.PriorityMedium, k.PriorityLow),
+			helpItem("期限", k.Due),
+			helpItem("メモ", k.LinkNote),
+			helpItem("選択解除", k.Unmark),
+		)
+	}
+	if m.isBoardView() {
+		entries = append(entries, helpItem("列移動", k.MoveLeft, k.MoveRight), helpItem("並べ替え", k.MoveDown, k.MoveUp))
+	}
+	quitLabel := "終了"
+	if m.embedded {
+		quitLabel = "戻る"
+	}
+	entries = append(entries,
+		helpItem("左右", k.Left, k.Right),
+		helpItem("上下", k.Down, k.Up),
+		helpItem("ヘルプ", k.Help),
+		helpItem("コマンド", k.Palette),
+		helpItem(quitLabel, k.Quit),
+	)
+	return helpLine(entries...)
+}
+
+func Run(manager *Manager, storage *note.Storage) error {
+	m, err := NewModel(manager, storage)
+	if err != nil {
+		return err
 	}
-	sortLabel := "s:期限順"
-	if m.sortByDue {
-		sortLabel = "s:優先度順"
+	opts := []tea.ProgramOption{tea.WithAltScreen()}
+	if config.Global.Display.Mouse {
+		opts = append(opts, tea.WithMouseCellMotion())
+	}
+	p := tea.NewProgram(m, opts...)
+
+	// 他の端末での変更を反映する (監視できなくても起動は続ける)
+	if w, err := util.Watch(p.Send, nil, []string{manager.FilePath()}); err == nil {
+		defer w.Close()
 	}
-	return fmt.Sprintf("i:追加 d:削除 Enter/Space:完了切替 %s h/l:左右 j/k:上下 q:終了", sortLabel)
-}
 
-func Run(manager *Manager) error {
-	p := tea.NewProgram(NewModel(manager), tea.WithAltScreen())
-	_, err := p.Run()
+	_, err = p.Run()
 	return err
 }
@@ -0,0 +1,129 @@
+package task
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/intiramisu/note-cli/internal/config"
+	"github.com/intiramisu/note-cli/internal/util"
+)
+
+// visible returns the tasks matching the active query.
+func (m *Model) visible(tasks []*Task) []*Task {
+	if m.query == nil {
+		return tasks
+	}
+	var matched []*Task
+	for _, t := range tasks {
+		if m.query.Match(t) {
+			matched = append(matched, t)
+		}
+	}
+	return matched
+}
+
+// setQuery replaces the query (nil shows every task) and keeps the cursor on
+// the current task when it is still shown.
+func (m *Model) setQuery(q *Query, viewName string) {
+	var cursorID int
+	if task := m.currentTask(); task != nil {
+		cursorID = task.ID
+	}
+	m.query = q
+	m.viewName = viewName
+	m.sel.Clear()
+	m.refreshTasks()
+	m.moveCursorToTask(cursorID)
+	m.adjustCursor()
+}
+
+// startFilter prompts for a query, filled in with the current one.
+func (m *Model) startFilter() tea.Cmd {
+	m.mode = modeFilter
+	m.filterInput.Reset()
+	if m.query != nil {
+		m.filterInput.SetValue(m.query.String())
+	}
+	m.filterInput.Focus()
+	return textinput.Blink
+}
+
+func (m *Model) endFilter() {
+	m.mode = modeNormal
+	m.filterInput.Reset()
+	m.filterInput.Blur()
+}
+
+// updateFilterMode applies the entered query; an empty value clears it.
+func (m Model) updateFilterMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, m.keys.Confirm):
+		value := strings.TrimSpace(m.filterInput.Value())
+		if value == "" {
+			m.setQuery(nil, "")
+			m.endFilter()
+			return m, nil
+		}
+		q, err := ParseQuery(value)
+		if err != nil {
+			m.status = err.Error()
+			return m, nil
+		}
+		m.setQuery(q, "")
+		m.endFilter()
+		return m, nil
+
+	case key.Matches(msg, m.keys.Cancel):
+		m.endFilter()
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.filterInput, cmd = m.filterInput.Update(msg)
+	return m, cmd
+}
+
+// startViews lists the views of task_views in the palette.
+func (m *Model) startViews() tea.Cmd {
+	views := config.Global.TaskViews
+	if len(views) == 0 {
+		m.status = "ビューが設定されていません (task_views)"
+		return nil
+	}
+	m.viewNames = make([]string, 0, len(views))
+	for name := range views {
+		m.viewNames = append(m.viewNames, name)
+	}
+	sort.Strings(m.viewNames)
+
+	items := make([]util.PaletteItem, len(m.viewNames))
+	for i, name := range m.viewNames {
+		items[i] = util.PaletteItem{Name: name, Keywords: views[name], Key: views[name]}
+	}
+	m.paletteOpen = true
+	return m.palette.OpenList("ビュー", items)
+}
+
+// finishViews applies the view chosen in startViews.
+func (m *Model) finishViews(chosen int) {
+	names := m.viewNames
+	m.viewNames = nil
+	if chosen < 0 {
+		return
+	}
+	name := names[chosen]
+	q, err := ParseQuery(config.Global.TaskViews[name])
+	if err != nil {
+		m.status = fmt.Sprintf("ビュー %s: %v", name, err)
+		return
+	}
+	m.setQuery(q, name)
+}
+
+func (m Model) renderFilterInput() string {
+	return "\n絞り込み: " + m.filterInput.View()
+}
@@ -0,0 +1,284 @@
+package ui
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/intiramisu/note-cli/internal/config"
+	"github.com/intiramisu/note-cli/internal/note"
+	"github.com/intiramisu/note-cli/internal/util"
+)
+
+type promptKind int
+
+const (
+	promptNone promptKind = iota
+	promptCreate
+	promptRename
+)
+
+// editorFinishedMsg is sent when the external editor exits.
+type editorFinishedMsg struct {
+	id      string
+	created bool
+	err     error
+}
+
+// noteEditing reports whether a create/rename/delete interaction has the keyboard.
+func (m model) noteEditing() bool {
+	return m.prompt != promptNone || m.pickingTemplate || m.confirmDelete
+}
+
+func (m *model) startPrompt(kind promptKind, value string) tea.Cmd {
+	m.prompt = kind
+	m.promptInput.SetValue(value)
+	m.promptInput.CursorEnd()
+	m.promptInput.Focus()
+	return textinput.Blink
+}
+
+func (m *model) endPrompt() {
+	m.prompt = promptNone
+	m.promptInput.Reset()
+	m.promptInput.Blur()
+}
+
+// editNote suspends the TUI and opens the note in the configured editor.
+func (m model) editNote(id string, created bool) tea.Cmd {
+	c := exec.Command(config.Global.Editor, m.noteStorage.GetPath(id))
+	return tea.ExecProcess(c, func(err error) tea.Msg {
+		return editorFinishedMsg{id: id, created: created, err: err}
+	})
+}
+
+// reloadNotes re-reads the notes right away, keeping the cursor on the same note.
+func (m *model) reloadNotes() {
+	notes, err := m.noteStorage.List("")
+	if err != nil {
+		m.status = err.Error()
+		return
+	}
+	m.setNotes(notes)
+}
+
+func (m *model) setNotes(notes []*note.Note) {
+	id := m.selectedNoteID()
+	m.allNotes = notes
+	m.applyFilter()
+	m.selectNoteByID(id)
+}
+
+// forgetNote drops id from the history, replacing it with newID when given.
+func (m *model) forgetNote(id, newID string) {
+	replace := func(ids []string) []string {
+		var out []string
+		for _, x := range ids {
+			switch {
+			case x != id:
+				out = append(out, x)
+			case newID != "":
+				out = append(out, newID)
+			}
+		}
+		return out
+	}
+	m.history = replace(m.history)
+	m.future = replace(m.future)
+}
+
+func (m model) handleEditorFinished(msg editorFinishedMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		m.status = "エディタの実行に失敗: " + msg.err.Error()
+	}
+	m.reloadNotes()
+	switch {
+	case msg.created && m.mode == modeNoteDetail:
+		m.visitNote(msg.id)
+	case msg.created:
+		m.history, m.future = nil, nil
+		m.showNote(msg.id)
+	case m.mode == modeNoteDetail:
+		m.showNote(msg.id)
+	}
+	return m, nil
+}
+
+func (m model) handleNoteEdit(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case m.confirmDelete:
+		return m.handleDeleteConfirm(msg)
+	case m.pickingTemplate:
+		return m.handleTemplatePick(msg)
+	}
+
+	k := m.keys
+	switch {
+	case key.Matches(msg, k.ForceQuit):
+		return m, tea.Quit
+
+	case key.Matches(msg, k.Cancel):
+		m.endPrompt()
+		return m, nil
+
+	case key.Matches(msg, k.Confirm):
+		title := strings.TrimSpace(m.promptInput.Value())
+		kind := m.prompt
+		m.endPrompt()
+		if title == "" {
+			return m, nil
+		}
+		if kind == promptRename {
+			m.renameNote(title)
+			return m, nil
+		}
+
+		names, _ := note.ListTemplates(config.Global.GetTemplatesPath())
+		if len(names) == 0 {
+			return m, m.createNote(title, "")
+		}
+		m.newTitle = title
+		m.templates = append([]string{""}, names...)
+		m.selectedTemplate = 0
+		m.pickingTemplate = true
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.promptInput, cmd = m.promptInput.Update(msg)
+	return m, cmd
+}
+
+func (m model) handleTemplatePick(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	k := m.keys
+	switch {
+	case key.Matches(msg, k.Down, k.NextItem):
+		if m.selectedTemplate < len(m.templates)-1 {
+			m.selectedTemplate++
+		}
+	case key.Matches(msg, k.Up, k.PrevItem):
+		if m.selectedTemplate > 0 {
+			m.selectedTemplate--
+		}
+	case key.Matches(msg, k.Select):
+		m.pickingTemplate = false
+		return m, m.createNote(m.newTitle, m.templates[m.selectedTemplate])
+	case key.Matches(msg, k.Close):
+		m.pickingTemplate = false
+	}
+	return m, nil
+}
+
+// createNote saves a new note and opens it in the editor.
+func (m *model) createNote(title, template string) tea.Cmd {
+	n := note.NewNote(title, nil)
+	if template != "" {
+		content, err := note.LoadTemplate(config.Global.GetTemplatesPath(), template, title)
+		if err != nil {
+			m.status = err.Error()
+			return nil
+		}
+		n.Content = content
+	}
+	if err := m.noteStorage.Create(n); err != nil {
+		m.status = err.Error()
+		return nil
+	}
+	m.status = "メモを作成しました: " + n.ID
+	return m.editNote(n.ID, true)
+}
+
+func (m *model) renameNote(title string) {
+	cur := m.currentNote()
+	if cur == nil {
+		return
+	}
+	renamed, err := m.noteStorage.Rename(cur.ID, title)
+	if err != nil {
+		m.status = err.Error()
+		return
+	}
+	if _, err := m.taskManager.RelinkNote(cur.ID, renamed.ID); err != nil {
+		m.status = err.Error()
+		return
+	}
+
+	m.status = fmt.Sprintf("名前を変更しました: %s → %s", cur.Title, renamed.Title)
+	m.forgetNote(cur.ID, renamed.ID)
+	m.reloadNotes()
+	if m.mode == modeNoteDetail {
+		m.showNote(renamed.ID)
+	} else {
+		m.selectNoteByID(renamed.ID)
+	}
+}
+
+func (m model) handleDeleteConfirm(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, m.keys.Yes):
+		m.confirmDelete = false
+		cur := m.currentNote()
+		if cur == nil {
+			return m, nil
+		}
+		if err := m.noteStorage.Delete(cur.ID); err != nil {
+			m.status = err.Error()
+			return m, nil
+		}
+		m.status = fmt.Sprintf("メモ「%s」を削除しました", cur.Title)
+		m.forgetNote(cur.ID, "")
+		m.mode = modeNotesList
+		m.reloadNotes()
+	case key.Matches(msg, m.keys.No):
+		m.confirmDelete = false
+	}
+	return m, nil
+}
+
+// renderNoteEdit draws the prompt, template list or delete confirmation
+// in place of the help line.
+func (m model) renderNoteEdit() string {
+	k := m.keys
+	var b strings.Builder
+	b.WriteString("\n")
+
+	switch {
+	case m.confirmDelete:
+		if cur := m.currentNote(); cur != nil {
+			b.WriteString(styles.Selected.Render(fmt.Sprintf("メモ「%s」を削除しますか? %s", cur.Title, util.YesNo(k.Yes, k.No))))
+		}
+
+	case m.pickingTemplate:
+		symbols := config.Global.Theme.Symbols
+		b.WriteString(styles.Meta.Render(fmt.Sprintf("「%s」のテンプレートを選択:", m.newTitle)))
+		b.WriteString("\n")
+		for i, name := range m.templates {
+			prefix := symbols.CursorEmpty
+			style := styles.Normal
+			if i == m.selectedTemplate {
+				prefix = symbols.Cursor
+				style = styles.Selected
+			}
+			if name == "" {
+				name = "(なし)"
+			}
+			b.WriteString(style.Render(prefix + util.TruncateString(name, m.width-4)))
+			b.WriteString("\n")
+		}
+		b.WriteString(styles.Meta.Render(helpLine(helpItem("移動", k.Down, k.Up), helpItem("作成", k.Select), helpItem("キャンセル", k.Close))))
+
+	case m.prompt == promptCreate:
+		b.WriteString("新規メモ: " + m.promptInput.View())
+		b.WriteString("\n")
+		b.WriteString(styles.Meta.Render(helpLine(helpItem("作成", k.Confirm), helpItem("", k.Cancel))))
+
+	case m.prompt == promptRename:
+		b.WriteString("新しいタイトル: " + m.promptInput.View())
+		b.WriteString("\n")
+		b.WriteString(styles.Meta.Render(helpLine(helpItem("変更", k.Confirm), helpItem("", k.Cancel))))
+	}
+	return b.String()
+}
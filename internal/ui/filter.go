@@ -0,0 +1,305 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/intiramisu/note-cli/internal/note"
+	"github.com/intiramisu/note-cli/internal/util"
+	"github.com/mattn/go-runewidth"
+)
+
+// noteMatch is why a note survived the filter, used for highlighting.
+type noteMatch struct {
+	label     string // パス+タイトル+タグ
+	positions []int  // label 内で一致したルーン位置
+	snippet   string // 全文検索で一致した行
+	snipPos   []int  // snippet 内で一致したルーン位置
+	score     int
+}
+
+// filterLabel is what the fuzzy filter matches against: the path, title and tags.
+func filterLabel(n *note.Note) string {
+	label := pickerLabel(n)
+	for _, tag := range n.Tags {
+		label += " #" + tag
+	}
+	return label
+}
+
+// matchNote matches pattern against the label and, with fullText, the note's lines.
+// Notes matched only by their content sort after every label match.
+func matchNote(n *note.Note, pattern string, fullText bool) (noteMatch, bool) {
+	m := noteMatch{label: filterLabel(n)}
+	score, positions, ok := util.FuzzyMatch(pattern, m.label)
+	if ok {
+		m.score, m.positions = score, positions
+	}
+	if fullText {
+		if line, pos, found := findLine(n.Content, pattern); found {
+			m.snippet, m.snipPos = line, pos
+			ok = true
+		}
+	}
+	return m, ok
+}
+
+// findLine returns the first line of content containing pattern (case-insensitive)
+// and the rune positions of the matches within the trimmed line.
+func findLine(content, pattern string) (string, []int, bool) {
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		if positions := matchPositions(line, pattern); len(positions) > 0 {
+			return line, positions, true
+		}
+	}
+	return "", nil, false
+}
+
+// matchPositions returns the rune positions of every case-insensitive occurrence of pattern in s.
+func matchPositions(s, pattern string) []int {
+	p := []rune(strings.ToLower(pattern))
+	if len(p) == 0 {
+		return nil
+	}
+	r := []rune(s)
+	var positions []int
+	for i := 0; i+len(p) <= len(r); i++ {
+		if runesEqualFold(r[i:i+len(p)], p) {
+			for j := range p {
+				positions = append(positions, i+j)
+			}
+			i += len(p) - 1
+		}
+	}
+	return positions
+}
+
+func runesEqualFold(a, b []rune) bool {
+	for i := range a {
+		if unicode.ToLower(a[i]) != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func (m model) filterActive() bool {
+	return m.filterInput.Value() != ""
+}
+
+// applyFilter rebuilds m.notes from m.allNotes, narrowed by the selected
+// tags and then by the fuzzy pattern, best matches first.
+func (m *model) applyFilter() {
+	candidates := m.allNotes
+	if len(m.filterTags) > 0 {
+		candidates = nil
+		for _, n := range m.allNotes {
+			if n.MatchTags(m.filterTags, m.tagMatchAll) {
+				candidates = append(candidates, n)
+			}
+		}
+	}
+
+	pattern := strings.TrimSpace(m.filterInput.Value())
+	if pattern == "" {
+		m.notes = candidates
+		m.noteMatches = nil
+	} else {
+		type scored struct {
+			n     *note.Note
+			match noteMatch
+		}
+		var matches []scored
+		for _, n := range candidates {
+			if match, ok := matchNote(n, pattern, m.fullText); ok {
+				matches = append(matches, scored{n, match})
+			}
+		}
+		sort.SliceStable(matches, func(i, j int) bool {
+			return matches[i].match.score > matches[j].match.score
+		})
+
+		m.notes = make([]*note.Note, len(matches))
+		m.noteMatches = make(map[string]noteMatch, len(matches))
+		for i, s := range matches {
+			m.notes[i] = s.n
+			m.noteMatches[s.n.ID] = s.match
+		}
+	}
+
+	if m.selectedNote >= len(m.notes) {
+		m.selectedNote = len(m.notes) - 1
+	}
+	if m.selectedNote < 0 {
+		m.selectedNote = 0
+	}
+}
+
+// selectNoteByID moves the cursor to the note with id, if it is listed.
+func (m *model) selectNoteByID(id string) {
+	for i, n := range m.notes {
+		if n.ID == id {
+			m.selectedNote = i
+			return
+		}
+	}
+}
+
+func (m model) selectedNoteID() string {
+	if m.selectedNote >= 0 && m.selectedNote < len(m.notes) {
+		return m.notes[m.selectedNote].ID
+	}
+	return ""
+}
+
+// clearFilter drops the filter, keeping the cursor on the same note.
+func (m *model) clearFilter() {
+	id := m.selectedNoteID()
+	m.filtering = false
+	m.filterInput.Reset()
+	m.filterInput.Blur()
+	m.applyFilter()
+	m.selectNoteByID(id)
+}
+
+func (m model) handleFilterInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	k := m.keys
+	switch {
+	case key.Matches(msg, k.ForceQuit):
+		return m, tea.Quit
+
+	case key.Matches(msg, k.Confirm):
+		m.filtering = false
+		m.filterInput.Blur()
+		return m, nil
+
+	case key.Matches(msg, k.ClearFilter):
+		m.clearFilter()
+		return m, nil
+
+	case key.Matches(msg, k.FilterDown):
+		m.moveDown()
+		return m, nil
+
+	case key.Matches(msg, k.FilterUp):
+		m.moveUp()
+		return m, nil
+
+	case key.Matches(msg, k.FullText):
+		m.fullText = !m.fullText
+		m.applyFilter()
+		m.selectedNote = 0
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	prev := m.filterInput.Value()
+	m.filterInput, cmd = m.filterInput.Update(msg)
+	if m.filterInput.Value() != prev {
+		m.applyFilter()
+		m.selectedNote = 0
+	}
+	return m, cmd
+}
+
+func (m *model) startFilter() tea.Cmd {
+	m.filtering = true
+	m.filterInput.Focus()
+	return textinput.Blink
+}
+
+// renderFilterLine shows the filter prompt and the number of matches.
+func (m model) renderFilterLine() string {
+	var b strings.Builder
+	if m.filtering {
+		b.WriteString(m.filterInput.View())
+	} else {
+		b.WriteString(styles.Meta.Render(m.filterInput.Prompt + m.filterInput.Value()))
+	}
+	info := fmt.Sprintf(" %d/%d", len(m.notes), len(m.allNotes))
+	if m.fullText {
+		info += " [全文]"
+	}
+	b.WriteString(styles.Meta.Render(info))
+	return b.String()
+}
+
+// highlight renders s with the runes at positions in hl and the rest in base,
+// truncated to maxWidth display columns ("..." marks the cut). It returns the
+// rendered string and its display width.
+func highlight(s string, positions []int, maxWidth int, base, hl lipgloss.Style) (string, int) {
+	hit := make(map[int]bool, len(positions))
+	for _, p := range positions {
+		hit[p] = true
+	}
+
+	runes := []rune(s)
+	limit := maxWidth
+	if runewidth.StringWidth(s) > maxWidth {
+		limit = maxWidth - 3
+	}
+
+	var b, seg strings.Builder
+	segHit := false
+	flush := func() {
+		if seg.Len() == 0 {
+			return
+		}
+		if segHit {
+			b.WriteString(hl.Render(seg.String()))
+		} else {
+			b.WriteString(base.Render(seg.String()))
+		}
+		seg.Reset()
+	}
+
+	width := 0
+	for i, r := range runes {
+		rw := runewidth.RuneWidth(r)
+		if width+rw > limit {
+			break
+		}
+		if hit[i] != segHit {
+			flush()
+			segHit = hit[i]
+		}
+		seg.WriteRune(r)
+		width += rw
+	}
+	flush()
+	if limit < maxWidth {
+		b.WriteString(base.Render("..."))
+		width += 3
+	}
+	return b.String(), width
+}
+
+// snippetWindow cuts the start of a long line so the match stays visible.
+func snippetWindow(line string, positions []int, maxWidth int) (string, []int) {
+	if len(positions) == 0 {
+		return line, positions
+	}
+	runes := []rune(line)
+	lead := runewidth.StringWidth(string(runes[:positions[0]]))
+	if lead <= maxWidth/3 {
+		return line, positions
+	}
+
+	cut := 0
+	for lead > maxWidth/3 && cut < positions[0] {
+		lead -= runewidth.RuneWidth(runes[cut])
+		cut++
+	}
+	shifted := make([]int, len(positions))
+	for i, p := range positions {
+		shifted[i] = p - cut + 3
+	}
+	return "..." + string(runes[cut:]), shifted
+}
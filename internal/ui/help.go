@@ -0,0 +1,78 @@
+package ui
+
+import (
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/intiramisu/note-cli/internal/util"
+)
+
+// helpSections lists the bindings of the current mode for the help overlay.
+func (m model) helpSections() (string, []util.HelpSection) {
+	k := m.keys
+	general := util.HelpSection{Title: "全般", Bindings: []key.Binding{k.Help, k.Palette, k.Quit}}
+	noteEdit := util.HelpSection{Title: "メモの操作", Bindings: []key.Binding{k.NewNote, k.EditNote, k.RenameNote, k.DeleteNote, k.Reader}}
+
+	switch m.mode {
+	case modeNoteDetail:
+		return "メモ詳細", []util.HelpSection{
+			{Title: "移動", Bindings: []key.Binding{k.Up, k.Down, k.Back, k.Links, k.HistoryBack, k.HistoryForward}},
+			{Title: "関連タスク", Bindings: []key.Binding{k.ToggleTask, k.AddTask, k.AttachTask, k.DeleteTask, k.UnlinkTask, k.PriorityHigh, k.PriorityMedium, k.PriorityLow, k.DueTask, k.MoveTask, k.Sort}},
+			{Title: "複数選択", Bindings: []key.Binding{k.MarkTask, k.VisualTask, k.UnmarkTask}},
+			{Title: "タスク入力", Bindings: []key.Binding{k.Confirm, k.Cancel, k.NextPriority, k.PrevPriority, k.SetDue}},
+			noteEdit,
+			general,
+		}
+
+	case modeAttachTask:
+		return "タスクの紐づけ", []util.HelpSection{
+			{Title: "操作", Bindings: []key.Binding{k.Up, k.Down, k.MarkTask, k.Select, k.Close}},
+		}
+
+	case modeTags:
+		return "タグパネル", []util.HelpSection{
+			{Title: "操作", Bindings: []key.Binding{k.Up, k.Down, k.ToggleTag, k.TagMatchAll, k.ClearTags, k.CloseTags}},
+		}
+
+	case modeLinks:
+		return "リンク選択", []util.HelpSection{
+			{Title: "操作", Bindings: []key.Binding{k.Up, k.Down, k.NextItem, k.PrevItem, k.Select, k.Close}},
+		}
+
+	case modeReader:
+		return "リーダー", []util.HelpSection{
+			{Title: "操作", Bindings: []key.Binding{k.Up, k.Down, k.PageDown, k.PageUp, k.Top, k.Bottom, k.Close}},
+			{Title: "検索", Bindings: []key.Binding{k.Search, k.NextMatch, k.PrevMatch, k.ClearSearch}},
+		}
+
+	case modeInbox, modeInboxMove:
+		return "インボックス", []util.HelpSection{
+			{Title: "操作", Bindings: []key.Binding{k.Up, k.Down, k.InboxTask, k.InboxMove, k.InboxDiscard, k.Close}},
+		}
+	}
+
+	return "メモ一覧", []util.HelpSection{
+		{Title: "移動", Bindings: []key.Binding{k.Up, k.Down, k.Select}},
+		{Title: "絞り込み・表示", Bindings: []key.Binding{k.Filter, k.ClearFilter, k.Tags, k.Preview, k.Inbox}},
+		noteEdit,
+		general,
+	}
+}
+
+func (m *model) openHelp() {
+	title, sections := m.helpSections()
+	m.help = util.NewHelpView("ヘルプ - "+title, sections, styles, m.width, m.height)
+	m.showHelp = true
+}
+
+func (m model) handleHelp(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if key.Matches(msg, m.keys.Help) {
+		m.showHelp = false
+		return m, nil
+	}
+	var closed bool
+	m.help, closed = m.help.Update(msg)
+	if closed {
+		m.showHelp = false
+	}
+	return m, nil
+}
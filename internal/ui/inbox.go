@@ -0,0 +1,269 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/intiramisu/note-cli/internal/config"
+	"github.com/intiramisu/note-cli/internal/note"
+	"github.com/intiramisu/note-cli/internal/task"
+	"github.com/intiramisu/note-cli/internal/util"
+)
+
+// openInbox loads the inbox entries (today's daily note or inbox.note).
+func (m *model) openInbox() {
+	cfg := config.Global
+	m.mode = modeInbox
+	m.selectedInbox = 0
+	m.inboxStatus = ""
+	m.confirmDiscard = false
+
+	m.inboxID = cfg.GetDailyNoteID(time.Now())
+	if cfg.Inbox.Note != "" {
+		n, err := note.PreferExact(m.noteStorage.Find(cfg.Inbox.Note))
+		if err != nil {
+			// 未作成なら空のインボックス、複数のメモに該当するなどその他は理由を表示する
+			if !errors.Is(err, note.ErrNotFound) {
+				m.inboxStatus = err.Error()
+			}
+			m.inboxID = ""
+			m.inboxEntries = nil
+			return
+		}
+		m.inboxID = n.ID
+	}
+	m.loadInboxEntries()
+}
+
+func (m *model) loadInboxEntries() {
+	entries, err := m.noteStorage.SectionItems(m.inboxID, config.Global.Inbox.Heading)
+	if err != nil {
+		entries = nil
+	}
+	m.inboxEntries = entries
+	if m.selectedInbox >= len(m.inboxEntries) {
+		m.selectedInbox = len(m.inboxEntries) - 1
+	}
+	if m.selectedInbox < 0 {
+		m.selectedInbox = 0
+	}
+}
+
+// removeInboxEntry drops the selected entry; the cursor then points at the next one.
+func (m *model) removeInboxEntry(entry string) {
+	if err := m.noteStorage.RemoveSectionItem(m.inboxID, config.Global.Inbox.Heading, entry); err != nil {
+		m.inboxStatus = err.Error()
+	}
+	m.loadInboxEntries()
+}
+
+func (m model) handleInbox(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.mode == modeInboxMove {
+		return m.handleInboxMove(msg)
+	}
+
+	var entry string
+	if m.selectedInbox < len(m.inboxEntries) {
+		entry = m.inboxEntries[m.selectedInbox]
+	}
+
+	k := m.keys
+	if m.confirmDiscard {
+		switch {
+		case key.Matches(msg, k.Yes):
+			m.confirmDiscard = false
+			m.inboxStatus = "破棄: " + inboxEntryText(entry)
+			m.removeInboxEntry(entry)
+		case key.Matches(msg, k.No):
+			m.confirmDiscard = false
+		}
+		return m, nil
+	}
+
+	switch {
+	case key.Matches(msg, k.Down):
+		if m.selectedInbox < len(m.inboxEntries)-1 {
+			m.selectedInbox++
+		}
+
+	case key.Matches(msg, k.Up):
+		if m.selectedInbox > 0 {
+			m.selectedInbox--
+		}
+
+	case key.Matches(msg, k.InboxTask):
+		if entry != "" {
+			desc := inboxEntryText(entry)
+			m.taskManager.Add(desc, task.PriorityMedium, "", time.Time{})
+			m.inboxStatus = "タスクに変換: " + desc
+			m.removeInboxEntry(entry)
+		}
+
+	case key.Matches(msg, k.InboxMove):
+		if entry != "" && len(m.allNotes) > 0 {
+			m.mode = modeInboxMove
+			m.moveTarget = 0
+		}
+
+	case key.Matches(msg, k.InboxDiscard):
+		if entry != "" {
+			m.inboxStatus = ""
+			m.confirmDiscard = true
+		}
+
+	case key.Matches(msg, k.Close):
+		m.mode = modeNotesList
+		return m, m.loadNotes
+	}
+
+	return m, nil
+}
+
+func (m model) handleInboxMove(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	k := m.keys
+	switch {
+	case key.Matches(msg, k.Down):
+		if m.moveTarget < len(m.allNotes)-1 {
+			m.moveTarget++
+		}
+
+	case key.Matches(msg, k.Up):
+		if m.moveTarget > 0 {
+			m.moveTarget--
+		}
+
+	case key.Matches(msg, k.Select):
+		target := m.allNotes[m.moveTarget]
+		entry := m.inboxEntries[m.selectedInbox]
+		m.mode = modeInbox
+		if target.ID == m.inboxID {
+			m.inboxStatus = "インボックス自身には移動できません"
+			return m, nil
+		}
+		if err := m.noteStorage.Append(target.ID, "- "+entry, ""); err != nil {
+			m.inboxStatus = err.Error()
+			return m, nil
+		}
+		m.inboxStatus = fmt.Sprintf("「%s」に移動: %s", target.Title, inboxEntryText(entry))
+		m.removeInboxEntry(entry)
+
+	case key.Matches(msg, k.Close):
+		m.mode = modeInbox
+	}
+
+	return m, nil
+}
+
+// inboxEntryText strips the capture timestamp from an entry.
+func inboxEntryText(entry string) string {
+	return util.StripTimestamp(entry, "15:04", config.Global.Formats.DateTime)
+}
+
+func (m model) renderInbox() string {
+	symbols := config.Global.Theme.Symbols
+
+	var b strings.Builder
+	b.WriteString(styles.Title.Render(fmt.Sprintf("📥 Inbox (%d)", len(m.inboxEntries))))
+	b.WriteString("\n")
+	b.WriteString(styles.Meta.Render(m.inboxID))
+	b.WriteString("\n\n")
+
+	if len(m.inboxEntries) == 0 {
+		b.WriteString(styles.Meta.Render("インボックスは空です"))
+		b.WriteString("\n")
+	} else {
+		maxItems := m.height - 8
+		if maxItems < 3 {
+			maxItems = 3
+		}
+		start := 0
+		if m.selectedInbox >= maxItems {
+			start = m.selectedInbox - maxItems + 1
+		}
+		end := start + maxItems
+		if end > len(m.inboxEntries) {
+			end = len(m.inboxEntries)
+		}
+
+		for i := start; i < end; i++ {
+			prefix := symbols.CursorEmpty
+			style := styles.Normal
+			if i == m.selectedInbox {
+				prefix = symbols.Cursor
+				style = styles.Selected
+			}
+			line := prefix + util.TruncateString(m.inboxEntries[i], m.width-4)
+			b.WriteString(style.Render(line))
+			b.WriteString("\n")
+		}
+	}
+
+	b.WriteString("\n")
+	if m.inboxStatus != "" {
+		b.WriteString(styles.Meta.Render(m.inboxStatus))
+		b.WriteString("\n")
+	}
+	k := m.keys
+	if m.confirmDiscard {
+		entry := inboxEntryText(m.inboxEntries[m.selectedInbox])
+		b.WriteString(styles.Selected.Render(fmt.Sprintf("「%s」を破棄しますか? %s", entry, util.YesNo(k.Yes, k.No))))
+	} else {
+		b.WriteString(styles.Help.Render(helpLine(
+			helpItem("移動", k.Down, k.Up),
+			helpItem("", k.InboxTask),
+			helpItem("", k.InboxMove),
+			helpItem("", k.InboxDiscard),
+			helpItem("戻る", k.Close),
+		)))
+	}
+
+	return b.String()
+}
+
+func (m model) renderInboxMove() string {
+	symbols := config.Global.Theme.Symbols
+
+	var b strings.Builder
+	b.WriteString(styles.Title.Render("📥 移動先のメモを選択"))
+	b.WriteString("\n")
+	b.WriteString(styles.Meta.Render(util.TruncateString(m.inboxEntries[m.selectedInbox], m.width-4)))
+	b.WriteString("\n\n")
+
+	maxItems := m.height - 8
+	if maxItems < 3 {
+		maxItems = 3
+	}
+	start := 0
+	if m.moveTarget >= maxItems {
+		start = m.moveTarget - maxItems + 1
+	}
+	end := start + maxItems
+	if end > len(m.allNotes) {
+		end = len(m.allNotes)
+	}
+
+	for i := start; i < end; i++ {
+		prefix := symbols.CursorEmpty
+		style := styles.Normal
+		if i == m.moveTarget {
+			prefix = symbols.Cursor
+			style = styles.Selected
+		}
+		b.WriteString(style.Render(prefix + util.TruncateString(m.allNotes[i].Title, m.width-4)))
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	k := m.keys
+	b.WriteString(styles.Help.Render(helpLine(
+		helpItem("選択", k.Down, k.Up),
+		helpItem("移動", k.Select),
+		helpItem("キャンセル", k.Close),
+	)))
+
+	return b.String()
+}
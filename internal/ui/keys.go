@@ -0,0 +1,287 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/intiramisu/note-cli/internal/util"
+)
+
+// keyMap holds the key bindings of every view of the integrated TUI. They
+// can be overridden in the keys.ui config section.
+type keyMap struct {
+	Quit    key.Binding
+	Help    key.Binding
+	Palette key.Binding
+	Up      key.Binding
+	Down    key.Binding
+	Select  key.Binding
+	Close   key.Binding
+	Reader  key.Binding
+
+	// メモ一覧
+	Filter      key.Binding
+	ClearFilter key.Binding
+	Preview     key.Binding
+	Tags        key.Binding
+	Inbox       key.Binding
+
+	// メモの作成・編集・名前変更・削除
+	NewNote    key.Binding
+	EditNote   key.Binding
+	RenameNote key.Binding
+	DeleteNote key.Binding
+
+	// メモ詳細
+	Back           key.Binding
+	Links          key.Binding
+	HistoryBack    key.Binding
+	HistoryForward key.Binding
+	ToggleTask     key.Binding
+	AddTask        key.Binding
+	AttachTask     key.Binding
+	DeleteTask     key.Binding
+	UnlinkTask     key.Binding
+	Sort           key.Binding
+
+	// 関連タスクの複数選択・一括操作
+	MarkTask       key.Binding
+	VisualTask     key.Binding
+	UnmarkTask     key.Binding
+	PriorityHigh   key.Binding
+	PriorityMedium key.Binding
+	PriorityLow    key.Binding
+	DueTask        key.Binding
+	MoveTask       key.Binding
+
+	// タスク入力
+	Confirm      key.Binding
+	Cancel       key.Binding
+	NextPriority key.Binding
+	PrevPriority key.Binding
+	SetDue       key.Binding
+
+	// 確認・入力中の共通操作
+	Yes       key.Binding
+	No        key.Binding
+	ForceQuit key.Binding // q が別の操作に使われる画面での終了
+	NextItem  key.Binding
+	PrevItem  key.Binding
+
+	// 絞り込み入力
+	FilterDown key.Binding
+	FilterUp   key.Binding
+	FullText   key.Binding
+
+	// タグパネル
+	ToggleTag   key.Binding
+	TagMatchAll key.Binding
+	ClearTags   key.Binding
+	CloseTags   key.Binding
+
+	// リーダー
+	PageDown    key.Binding
+	PageUp      key.Binding
+	Top         key.Binding
+	Bottom      key.Binding
+	Search      key.Binding
+	NextMatch   key.Binding
+	PrevMatch   key.Binding
+	ClearSearch key.Binding
+
+	// インボックス
+	InboxTask    key.Binding
+	InboxMove    key.Binding
+	InboxDiscard key.Binding
+}
+
+// newKeyMap returns the default bindings with the configured overrides applied.
+func newKeyMap(overrides map[string][]string) (keyMap, error) {
+	k := keyMap{
+		Quit:    util.NewBinding("", "終了", "q", "ctrl+c"),
+		Help:    util.NewBinding("", "ヘルプ", "?"),
+		Palette: util.NewBinding(":/Ctrl+P", "コマンド", ":", "ctrl+p"),
+		Up:      util.NewBinding("", "上へ", "k", "up"),
+		Down:    util.NewBinding("", "下へ", "j", "down"),
+		Select:  util.NewBinding("", "開く", "enter"),
+		Close:   util.NewBinding("", "閉じる", "esc", "q"),
+		Reader:  util.NewBinding("", "全文", "v"),
+
+		Filter:      util.NewBinding("", "絞り込み", "/"),
+		ClearFilter: util.NewBinding("", "絞り込み解除", "esc"),
+		Preview:     util.NewBinding("", "プレビュー", "p"),
+		Tags:        util.NewBinding("", "タグ", "t"),
+		Inbox:       util.NewBinding("", "インボックス", "I"),
+
+		NewNote:    util.NewBinding("", "新規", "n"),
+		EditNote:   util.NewBinding("", "編集", "e"),
+		RenameNote: util.NewBinding("", "改名", "R"),
+		DeleteNote: util.NewBinding("", "削除", "D"),
+
+		Back:           util.NewBinding("Tab/Esc", "一覧", "tab", "esc"),
+		Links:          util.NewBinding("", "リンク", "l"),
+		HistoryBack:    util.NewBinding("", "戻る", "[", "backspace"),
+		HistoryForward: util.NewBinding("", "進む", "]"),
+		ToggleTask:     util.NewBinding("", "完了切替", "enter"),
+		AddTask:        util.NewBinding("", "追加", "i"),
+		AttachTask:     util.NewBinding("", "紐づけ", "a"),
+		DeleteTask:     util.NewBinding("", "削除", "d", "x"),
+		UnlinkTask:     util.NewBinding("", "解除", "o"),
+		Sort:           util.NewBinding("", "並び順", "s"),
+
+		MarkTask:       util.NewBinding("", "印を付ける/外す", " "),
+		VisualTask:     util.NewBinding("", "範囲選択", "V"),
+		UnmarkTask:     util.NewBinding("", "選択解除", "esc"),
+		PriorityHigh:   util.NewBinding("", "優先度をP1に", "1"),
+		PriorityMedium: util.NewBinding("", "優先度をP2に", "2"),
+		PriorityLow:    util.NewBinding("", "優先度をP3に", "3"),
+		DueTask:        util.NewBinding("", "期限を変更", "t"),
+		MoveTask:       util.NewBinding("", "別のメモへ移動", "m"),
+
+		Confirm:      util.NewBinding("", "確定", "enter"),
+		Cancel:       util.NewBinding("", "キャンセル", "esc"),
+		NextPriority: util.NewBinding("", "優先度変更", "tab"),
+		PrevPriority: util.NewBinding("", "優先度を戻す", "shift+tab"),
+		SetDue:       util.NewBinding("", "期限設定", "ctrl+d"),
+
+		Yes:       util.NewBinding("", "はい", "y", "Y"),
+		No:        util.NewBinding("", "いいえ", "n", "N", "esc", "q"),
+		ForceQuit: util.NewBinding("", "終了", "ctrl+c"),
+		NextItem:  util.NewBinding("", "次へ", "tab"),
+		PrevItem:  util.NewBinding("", "前へ", "shift+tab"),
+
+		FilterDown: util.NewBinding("", "下へ", "down", "ctrl+n", "ctrl+j"),
+		FilterUp:   util.NewBinding("", "上へ", "up", "ctrl+p", "ctrl+k"),
+		FullText:   util.NewBinding("", "全文検索", "ctrl+f"),
+
+		ToggleTag:   util.NewBinding("", "選択", " ", "x"),
+		TagMatchAll: util.NewBinding("", "AND/OR 切替", "a"),
+		ClearTags:   util.NewBinding("", "解除", "c"),
+		CloseTags:   util.NewBinding("Enter/Esc", "一覧へ", "enter", "esc", "t", "q"),
+
+		PageDown:    util.NewBinding("", "ページ送り", " ", "f", "pgdown"),
+		PageUp:      util.NewBinding("", "ページ戻し", "b", "pgup"),
+		Top:         util.NewBinding("", "先頭", "g", "home"),
+		Bottom:      util.NewBinding("", "末尾", "G", "end"),
+		Search:      util.NewBinding("", "検索", "/"),
+		NextMatch:   util.NewBinding("", "次の一致", "n"),
+		PrevMatch:   util.NewBinding("", "前の一致", "N"),
+		ClearSearch: util.NewBinding("", "検索解除", "esc"),
+
+		InboxTask:    util.NewBinding("", "タスクに変換", "t"),
+		InboxMove:    util.NewBinding("", "別のメモへ移動", "m"),
+		InboxDiscard: util.NewBinding("", "破棄", "d", "x"),
+	}
+	err := util.ApplyKeys(k.bindings(), overrides)
+	return k, err
+}
+
+// paletteKeys returns the bindings used inside the command palette.
+func (k keyMap) paletteKeys() util.PaletteKeys {
+	return util.PaletteKeys{
+		Confirm: k.Confirm,
+		Cancel:  k.Cancel,
+		Quit:    k.ForceQuit,
+		Up:      k.FilterUp,
+		Down:    k.FilterDown,
+		Next:    k.NextItem,
+		Prev:    k.PrevItem,
+	}
+}
+
+// bindings maps the action names used in the keys.ui config section to the bindings.
+func (k *keyMap) bindings() map[string]*key.Binding {
+	return map[string]*key.Binding{
+		"quit":            &k.Quit,
+		"help":            &k.Help,
+		"palette":         &k.Palette,
+		"up":              &k.Up,
+		"down":            &k.Down,
+		"select":          &k.Select,
+		"close":           &k.Close,
+		"reader":          &k.Reader,
+		"filter":          &k.Filter,
+		"clear_filter":    &k.ClearFilter,
+		"preview":         &k.Preview,
+		"tags":            &k.Tags,
+		"inbox":           &k.Inbox,
+		"new_note":        &k.NewNote,
+		"edit_note":       &k.EditNote,
+		"rename_note":     &k.RenameNote,
+		"delete_note":     &k.DeleteNote,
+		"back":            &k.Back,
+		"links":           &k.Links,
+		"history_back":    &k.HistoryBack,
+		"history_forward": &k.HistoryForward,
+		"toggle_task":     &k.ToggleTask,
+		"add_task":        &k.AddTask,
+		"attach_task":     &k.AttachTask,
+		"delete_task":     &k.DeleteTask,
+		"unlink_task":     &k.UnlinkTask,
+		"sort":            &k.Sort,
+		"mark_task":       &k.MarkTask,
+		"visual_task":     &k.VisualTask,
+		"unmark_task":     &k.UnmarkTask,
+		"priority_high":   &k.PriorityHigh,
+		"priority_medium": &k.PriorityMedium,
+		"priority_low":    &k.PriorityLow,
+		"due_task":        &k.DueTask,
+		"move_task":       &k.MoveTask,
+		"confirm":         &k.Confirm,
+		"cancel":          &k.Cancel,
+		"next_priority":   &k.NextPriority,
+		"prev_priority":   &k.PrevPriority,
+		"set_due":         &k.SetDue,
+		"yes":             &k.Yes,
+		"no":              &k.No,
+		"force_quit":      &k.ForceQuit,
+		"next_item":       &k.NextItem,
+		"prev_item":       &k.PrevItem,
+		"filter_down":     &k.FilterDown,
+		"filter_up":       &k.FilterUp,
+		"full_text":       &k.FullText,
+		"toggle_tag":      &k.ToggleTag,
+		"tag_match_all":   &k.TagMatchAll,
+		"clear_tags":      &k.ClearTags,
+		"close_tags":      &k.CloseTags,
+		"page_down":       &k.PageDown,
+		"page_up":         &k.PageUp,
+		"top":             &k.Top,
+		"bottom":          &k.Bottom,
+		"search":          &k.Search,
+		"next_match":      &k.NextMatch,
+		"prev_match":      &k.PrevMatch,
+		"clear_search":    &k.ClearSearch,
+		"inbox_task":      &k.InboxTask,
+		"inbox_move":      &k.InboxMove,
+		"inbox_discard":   &k.InboxDiscard,
+	}
+}
+
+// helpEntry is one "keys: description" item of a help line.
+type helpEntry struct {
+	desc     string
+	bindings []key.Binding
+}
+
+// helpItem describes bindings with desc; an empty desc joins their own descriptions.
+func helpItem(desc string, bindings ...key.Binding) helpEntry {
+	return helpEntry{desc: desc, bindings: bindings}
+}
+
+// helpLine joins the entries with " | ", leaving out disabled bindings.
+func helpLine(entries ...helpEntry) string {
+	var parts []string
+	for _, e := range entries {
+		keys := util.HelpKeys(e.bindings...)
+		if keys == "" {
+			continue
+		}
+		desc := e.desc
+		if desc == "" {
+			desc = util.HelpDesc(e.bindings...)
+		}
+		parts = append(parts, keys+": "+desc)
+	}
+	return strings.Join(parts, " | ")
+}
@@ -0,0 +1,229 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/intiramisu/note-cli/internal/config"
+	"github.com/intiramisu/note-cli/internal/note"
+	"github.com/intiramisu/note-cli/internal/util"
+)
+
+// linkEntry is a selectable [[link]] or backlink in the note detail.
+type linkEntry struct {
+	name      string
+	target    *note.Note           // リンク先が存在しない・特定できない場合は nil
+	ambiguous *note.AmbiguousError // 複数のメモに該当した場合
+	backlink  bool
+}
+
+// resolveLink finds the note a [[name]] points to. Ambiguous names resolve
+// to the exact title when there is one and are otherwise kept unresolved.
+func (m *model) resolveLink(name string) linkEntry {
+	entry := linkEntry{name: name}
+	n, err := note.PreferExact(m.noteStorage.Find(name))
+	if err == nil {
+		entry.target = n
+	}
+	errors.As(err, &entry.ambiguous)
+	return entry
+}
+
+// loadLinks collects the links and backlinks of the selected note.
+func (m *model) loadLinks() {
+	m.links = nil
+	m.selectedLink = 0
+	n := m.currentNote()
+	if n == nil {
+		return
+	}
+	for _, name := range note.ExtractLinks(n.Content) {
+		m.links = append(m.links, m.resolveLink(name))
+	}
+	backlinks, _ := note.FindBacklinks(m.noteStorage, n.Title)
+	for _, bl := range backlinks {
+		m.links = append(m.links, linkEntry{name: bl.Title, target: bl, backlink: true})
+	}
+}
+
+func (m model) currentNote() *note.Note {
+	if m.selectedNote >= 0 && m.selectedNote < len(m.notes) {
+		return m.notes[m.selectedNote]
+	}
+	return nil
+}
+
+// showNote opens the detail of the note with id. Notes hidden by the filter
+// clear it, and notes created since the last load are picked up by reloading.
+func (m *model) showNote(id string) {
+	if m.selectedNoteID() != id {
+		m.selectNoteByID(id)
+	}
+	if m.selectedNoteID() != id && (m.filterActive() || len(m.filterTags) > 0) {
+		m.clearFilter()
+		m.clearTagFilter()
+		m.selectNoteByID(id)
+	}
+	if m.selectedNoteID() != id {
+		if notes, err := m.noteStorage.List(""); err == nil {
+			m.allNotes = notes
+			m.applyFilter()
+			m.selectNoteByID(id)
+		}
+	}
+
+	m.mode = modeNoteDetail
+	m.selectedTask = 0
+	m.taskSel.Clear()
+	m.loadRelatedTasks()
+	m.loadLinks()
+}
+
+// visitNote opens id and records the current note in the history.
+func (m *model) visitNote(id string) {
+	if cur := m.selectedNoteID(); cur != "" {
+		m.history = append(m.history, cur)
+	}
+	m.future = nil
+	m.showNote(id)
+}
+
+func (m *model) historyBack() {
+	if len(m.history) == 0 {
+		return
+	}
+	m.future = append(m.future, m.selectedNoteID())
+	id := m.history[len(m.history)-1]
+	m.history = m.history[:len(m.history)-1]
+	m.showNote(id)
+}
+
+func (m *model) historyForward() {
+	if len(m.future) == 0 {
+		return
+	}
+	m.history = append(m.history, m.selectedNoteID())
+	id := m.future[len(m.future)-1]
+	m.future = m.future[:len(m.future)-1]
+	m.showNote(id)
+}
+
+func (m model) handleLinks(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	k := m.keys
+	if m.pendingCreate != "" {
+		switch {
+		case key.Matches(msg, k.Yes, k.Confirm):
+			n := note.NewNote(m.pendingCreate, nil)
+			if err := m.noteStorage.Save(n); err != nil {
+				m.status = err.Error()
+			} else {
+				m.status = "メモを作成しました: " + n.ID
+				m.visitNote(n.ID)
+			}
+			m.pendingCreate = ""
+		case key.Matches(msg, k.No):
+			m.pendingCreate = ""
+		}
+		return m, nil
+	}
+
+	switch {
+	case key.Matches(msg, k.ForceQuit):
+		return m, tea.Quit
+
+	case key.Matches(msg, k.Down, k.NextItem):
+		if m.selectedLink < len(m.links)-1 {
+			m.selectedLink++
+		}
+
+	case key.Matches(msg, k.Up, k.PrevItem):
+		if m.selectedLink > 0 {
+			m.selectedLink--
+		}
+
+	case key.Matches(msg, k.Select):
+		if m.selectedLink < len(m.links) {
+			link := m.links[m.selectedLink]
+			if link.ambiguous != nil {
+				m.status = link.ambiguous.Error()
+				return m, nil
+			}
+			if link.target == nil {
+				m.pendingCreate = link.name
+				return m, nil
+			}
+			m.visitNote(link.target.ID)
+		}
+
+	case key.Matches(msg, k.Close, k.Links):
+		m.mode = modeNoteDetail
+	}
+
+	return m, nil
+}
+
+// renderLinks draws the links and backlinks of the detail view; in link
+// mode each entry gets its own line with a cursor.
+func (m model) renderLinks(b *strings.Builder) {
+	var links, backlinks []int
+	for i, l := range m.links {
+		if l.backlink {
+			backlinks = append(backlinks, i)
+		} else {
+			links = append(links, i)
+		}
+	}
+
+	section := func(label string, idx []int) {
+		if len(idx) == 0 {
+			return
+		}
+		if m.mode != modeLinks {
+			var parts []string
+			for _, i := range idx {
+				parts = append(parts, m.linkLabel(m.links[i]))
+			}
+			b.WriteString(styles.Meta.Render(label + ": " + strings.Join(parts, ", ")))
+			b.WriteString("\n")
+			return
+		}
+
+		symbols := config.Global.Theme.Symbols
+		b.WriteString(styles.Meta.Render(label + ":"))
+		b.WriteString("\n")
+		for _, i := range idx {
+			prefix := symbols.CursorEmpty
+			style := styles.Normal
+			if i == m.selectedLink {
+				prefix = symbols.Cursor
+				style = styles.Selected
+			}
+			b.WriteString(style.Render("  " + prefix + m.linkLabel(m.links[i])))
+			b.WriteString("\n")
+		}
+	}
+
+	if len(m.links) > 0 {
+		b.WriteString("\n")
+	}
+	section("🔗 リンク先", links)
+	section("🔙 被参照", backlinks)
+
+	if m.pendingCreate != "" {
+		b.WriteString(styles.Selected.Render(fmt.Sprintf("「%s」を作成しますか? %s", m.pendingCreate, util.YesNo(m.keys.Yes, m.keys.No))))
+		b.WriteString("\n")
+	}
+}
+
+func (m model) linkLabel(l linkEntry) string {
+	if l.ambiguous != nil {
+		return fmt.Sprintf("%s(%d 件該当)", l.name, len(l.ambiguous.Candidates))
+	}
+	if l.target == nil {
+		return l.name + "(?)"
+	}
+	return l.target.Title
+}
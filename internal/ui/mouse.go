@@ -0,0 +1,153 @@
+package ui
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/intiramisu/note-cli/internal/config"
+	"github.com/mattn/go-runewidth"
+)
+
+// ホイール1回でスクロールするプレビューの行数
+const previewScrollLines = 3
+
+// handleMouse selects notes and tasks by clicking, toggles a task on its
+// checkbox and scrolls lists, the preview and the reader with the wheel.
+func (m model) handleMouse(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
+	if m.showHelp {
+		m.help, _ = m.help.Update(msg)
+		return m, nil
+	}
+	if m.paletteOpen {
+		return m.handlePalette(msg)
+	}
+	if m.inputActive() {
+		return m, nil
+	}
+
+	switch m.mode {
+	case modeNotesList:
+		return m.handleListMouse(msg)
+	case modeNoteDetail:
+		return m.handleDetailMouse(msg)
+	case modeReader:
+		var cmd tea.Cmd
+		m.reader, cmd = m.reader.Update(msg)
+		return m, cmd
+	}
+	return m, nil
+}
+
+func clicked(msg tea.MouseMsg) bool {
+	return msg.Button == tea.MouseButtonLeft && msg.Action == tea.MouseActionPress
+}
+
+// handleListMouse selects the clicked note; clicking the selected note opens it.
+func (m model) handleListMouse(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
+	listWidth := m.width
+	if m.previewVisible() {
+		listWidth, _ = m.previewWidths()
+		// 一覧の右の区切り線 " │ " より右はプレビュー
+		left := strings.TrimRight(m.renderNoteRows(listWidth), "\n")
+		if msg.X >= lipgloss.Width(left)+3 {
+			m.scrollPreview(msg)
+			return m, nil
+		}
+	}
+
+	switch msg.Button {
+	case tea.MouseButtonWheelUp:
+		m.moveUp()
+	case tea.MouseButtonWheelDown:
+		m.moveDown()
+	case tea.MouseButtonLeft:
+		if !clicked(msg) {
+			return m, nil
+		}
+		i, ok := m.noteAt(msg.Y, listWidth)
+		if !ok {
+			return m, nil
+		}
+		m.status = ""
+		if i != m.selectedNote {
+			m.selectedNote = i
+			return m, nil
+		}
+		m.history, m.future = nil, nil
+		m.showNote(m.selectedNoteID())
+	}
+	return m, nil
+}
+
+// noteAt returns the index of the note drawn on screen row y.
+func (m model) noteAt(y, width int) (int, bool) {
+	row := y - strings.Count(m.renderNoteRowsHeader(width), "\n")
+	start, end, rowsPerItem := m.visibleNotes()
+	if row < 0 || start+row/rowsPerItem >= end {
+		return 0, false
+	}
+	return start + row/rowsPerItem, true
+}
+
+// scrollPreview scrolls the preview pane of the selected note.
+func (m *model) scrollPreview(msg tea.MouseMsg) {
+	n := m.currentNote()
+	if n == nil {
+		return
+	}
+	if m.previewID != n.ID {
+		m.previewID, m.previewOffset = n.ID, 0
+	}
+	_, width := m.previewWidths()
+	room := m.previewHeight() - previewHeaderHeight
+	maxOffset := max(len(m.renderContent(n, width))-room, 0)
+
+	switch msg.Button {
+	case tea.MouseButtonWheelUp:
+		m.previewOffset = max(m.previewOffset-previewScrollLines, 0)
+	case tea.MouseButtonWheelDown:
+		m.previewOffset = min(m.previewOffset+previewScrollLines, maxOffset)
+	}
+}
+
+// handleDetailMouse selects the clicked related task, toggling it when the
+// checkbox is clicked.
+func (m model) handleDetailMouse(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
+	switch msg.Button {
+	case tea.MouseButtonWheelUp:
+		m.moveUp()
+	case tea.MouseButtonWheelDown:
+		m.moveDown()
+	case tea.MouseButtonLeft:
+		if !clicked(msg) {
+			return m, nil
+		}
+		i, onCheckbox, ok := m.taskAt(msg.X, msg.Y)
+		if !ok {
+			return m, nil
+		}
+		m.status = ""
+		m.selectedTask = i
+		if onCheckbox && m.keys.ToggleTask.Enabled() {
+			m.toggleTask()
+		}
+	}
+	return m, nil
+}
+
+// taskAt returns the index of the related task drawn on screen row y and
+// whether x is on its checkbox.
+func (m model) taskAt(x, y int) (int, bool, bool) {
+	if m.currentNote() == nil {
+		return 0, false, false
+	}
+	row := y - strings.Count(m.renderDetailHeader(), "\n")
+	if row < 0 || row >= min(len(m.tasks), m.detailTaskRows()) {
+		return 0, false, false
+	}
+	symbols := config.Global.Theme.Symbols
+	checkboxX := runewidth.StringWidth(symbols.CursorEmpty)
+	onCheckbox := x >= checkboxX && x < checkboxX+runewidth.StringWidth(symbols.CheckboxEmpty)
+	return row, onCheckbox, true
+}
@@ -0,0 +1,189 @@
+package ui
+
+import (
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/intiramisu/note-cli/internal/config"
+	"github.com/intiramisu/note-cli/internal/task"
+	"github.com/intiramisu/note-cli/internal/util"
+)
+
+// paletteAction is an entry of the command palette. Actions without a
+// binding (nil) have no key of their own.
+type paletteAction struct {
+	name     string
+	keywords string
+	binding  *key.Binding
+	run      func(m *model) tea.Cmd
+}
+
+// paletteActions lists the actions available in the current mode; actions
+// whose binding is disabled are left out.
+func (m model) paletteActions() []paletteAction {
+	k := m.keys
+	toList := func(m *model) { m.mode = modeNotesList }
+
+	actions := []paletteAction{
+		{"新規メモ", "new_note create", &k.NewNote, func(m *model) tea.Cmd { return m.startPrompt(promptCreate, "") }},
+		{"今日のデイリーノートを開く", "daily today", nil, func(m *model) tea.Cmd { m.openDaily(); return nil }},
+		{"タスクボードを開く", "task board kanban", nil, func(m *model) tea.Cmd { m.openBoard(); return nil }},
+		{"メモを絞り込み", "filter search", &k.Filter, func(m *model) tea.Cmd { toList(m); return m.startFilter() }},
+		{"タグで絞り込み", "tags filter", &k.Tags, func(m *model) tea.Cmd { toList(m); m.openTags(); return nil }},
+		{"インボックスを処理", "inbox", &k.Inbox, func(m *model) tea.Cmd { m.openInbox(); return nil }},
+	}
+
+	if cur := m.currentNote(); cur != nil {
+		actions = append(actions,
+			paletteAction{"全文を表示", "reader view", &k.Reader, func(m *model) tea.Cmd { m.openReader(); return nil }},
+			paletteAction{"メモを編集", "edit_note editor", &k.EditNote, func(m *model) tea.Cmd { return m.editNote(m.selectedNoteID(), false) }},
+			paletteAction{"メモの名前を変更", "rename_note", &k.RenameNote, func(m *model) tea.Cmd { return m.startPrompt(promptRename, cur.Title) }},
+			paletteAction{"メモを削除", "delete_note", &k.DeleteNote, func(m *model) tea.Cmd { m.confirmDelete = true; return nil }},
+		)
+	}
+
+	if m.mode == modeNotesList {
+		actions = append(actions,
+			paletteAction{"プレビューの表示切替", "preview toggle", &k.Preview, func(m *model) tea.Cmd { m.showPreview = !m.showPreview; return nil }},
+		)
+		if m.filterActive() || len(m.filterTags) > 0 {
+			actions = append(actions, paletteAction{"絞り込みを解除", "clear_filter", &k.ClearFilter, func(m *model) tea.Cmd {
+				m.clearFilter()
+				m.clearTagFilter()
+				return nil
+			}})
+		}
+	}
+
+	if m.mode == modeNoteDetail {
+		sortName := "タスクを期限順に並べる"
+		if m.sortByDue {
+			sortName = "タスクを優先度順に並べる"
+		}
+		actions = append(actions,
+			paletteAction{"タスクを追加", "add_task", &k.AddTask, func(m *model) tea.Cmd { return m.startAddTask() }},
+			paletteAction{"既存のタスクを紐づけ", "attach_task link", &k.AttachTask, func(m *model) tea.Cmd { m.openAttachTask(); return nil }},
+		)
+		if len(m.tasks) > 0 {
+			actions = append(actions,
+				paletteAction{"タスクの完了を切替", "toggle_task done", &k.ToggleTask, func(m *model) tea.Cmd { m.toggleTasks(); return nil }},
+				paletteAction{"タスクを削除", "delete_task remove", &k.DeleteTask, func(m *model) tea.Cmd { m.deleteTasks(); return nil }},
+				paletteAction{"タスクの紐づけを解除", "unlink_task", &k.UnlinkTask, func(m *model) tea.Cmd { m.unlinkTasks(); return nil }},
+				paletteAction{"タスクの優先度を P1 にする", "priority high", &k.PriorityHigh, func(m *model) tea.Cmd { m.setTaskPriority(task.PriorityHigh); return nil }},
+				paletteAction{"タスクの優先度を P2 にする", "priority medium", &k.PriorityMedium, func(m *model) tea.Cmd { m.setTaskPriority(task.PriorityMedium); return nil }},
+				paletteAction{"タスクの優先度を P3 にする", "priority low", &k.PriorityLow, func(m *model) tea.Cmd { m.setTaskPriority(task.PriorityLow); return nil }},
+				paletteAction{"タスクの期限を変更", "due_task date", &k.DueTask, func(m *model) tea.Cmd { return m.startTaskDue() }},
+				paletteAction{"タスクを別のメモへ移動", "move_task relink", &k.MoveTask, func(m *model) tea.Cmd { return m.startMoveTasks() }},
+				paletteAction{"タスクの範囲選択", "visual_task select range", &k.VisualTask, func(m *model) tea.Cmd { m.toggleVisualTasks(); return nil }},
+			)
+		}
+		if m.taskSel.Active() {
+			actions = append(actions, paletteAction{"タスクの選択を解除", "unmark_task clear", &k.UnmarkTask, func(m *model) tea.Cmd { m.taskSel.Clear(); return nil }})
+		}
+		actions = append(actions,
+			paletteAction{sortName, "sort toggle", &k.Sort, func(m *model) tea.Cmd {
+				m.sortByDue = !m.sortByDue
+				m.loadRelatedTasks()
+				return nil
+			}},
+		)
+		if len(m.links) > 0 {
+			actions = append(actions, paletteAction{"リンクを選択", "links", &k.Links, func(m *model) tea.Cmd { m.mode = modeLinks; return nil }})
+		}
+		actions = append(actions, paletteAction{"メモ一覧に戻る", "back list", &k.Back, func(m *model) tea.Cmd { m.mode = modeNotesList; return nil }})
+	}
+
+	actions = append(actions,
+		paletteAction{"ヘルプを表示", "help keys", &k.Help, func(m *model) tea.Cmd { m.openHelp(); return nil }},
+		paletteAction{"終了", "quit exit", &k.Quit, func(m *model) tea.Cmd { return tea.Quit }},
+	)
+
+	var enabled []paletteAction
+	for _, a := range actions {
+		if a.binding == nil || a.binding.Enabled() {
+			enabled = append(enabled, a)
+		}
+	}
+	return enabled
+}
+
+func (m *model) openPalette() tea.Cmd {
+	m.actions = m.paletteActions()
+	items := make([]util.PaletteItem, len(m.actions))
+	for i, a := range m.actions {
+		items[i] = util.PaletteItem{Name: a.name, Keywords: a.keywords}
+		if a.binding != nil {
+			items[i].Key = util.HelpKeys(*a.binding)
+		}
+	}
+	m.paletteOpen = true
+	return m.palette.Open(items)
+}
+
+func (m model) handlePalette(msg tea.Msg) (tea.Model, tea.Cmd) {
+	chosen, closed, cmd := m.palette.Update(msg)
+	if !closed {
+		return m, cmd
+	}
+	m.paletteOpen = false
+	if m.moveTasks != nil {
+		m.finishMoveTasks(chosen)
+		return m, nil
+	}
+	if chosen < 0 {
+		return m, nil
+	}
+	return m, m.actions[chosen].run(&m)
+}
+
+// openDaily opens today's daily note, creating it from the template if needed.
+func (m *model) openDaily() {
+	id, created, err := m.noteStorage.EnsureDaily(config.Global, time.Now())
+	if err != nil {
+		m.status = err.Error()
+		return
+	}
+	if created {
+		m.status = "デイリーノートを作成しました: " + id
+		m.reloadNotes()
+	}
+	if m.mode == modeNoteDetail {
+		m.visitNote(id)
+		return
+	}
+	m.history, m.future = nil, nil
+	m.showNote(id)
+}
+
+// openBoard shows the task board; closing it returns to the current mode.
+func (m *model) openBoard() {
+	board, err := task.NewEmbeddedModel(m.taskManager, m.noteStorage)
+	if err != nil {
+		m.status = err.Error()
+		return
+	}
+	updated, _ := board.Update(tea.WindowSizeMsg{Width: m.width, Height: m.height})
+	m.board = updated.(task.Model)
+	m.boardReturn = m.mode
+	m.mode = modeBoard
+}
+
+// updateBoard forwards messages to the task board until it is closed.
+func (m model) updateBoard(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case task.ClosedMsg:
+		m.mode = m.boardReturn
+		m.loadRelatedTasks()
+		return m, nil
+	case util.FilesChangedMsg:
+		// タスクはボードが読み直す
+		m.reloadNotes()
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+	}
+	updated, cmd := m.board.Update(msg)
+	m.board = updated.(task.Model)
+	return m, cmd
+}
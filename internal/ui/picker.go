@@ -0,0 +1,167 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/intiramisu/note-cli/internal/config"
+	"github.com/intiramisu/note-cli/internal/note"
+	"github.com/intiramisu/note-cli/internal/util"
+)
+
+// ErrPickCanceled is returned by PickNote when the picker is closed without a choice.
+var ErrPickCanceled = errors.New("キャンセルしました")
+
+const pickerMaxItems = 10
+
+type pickerModel struct {
+	query      string
+	candidates []*note.Note
+	filtered   []*note.Note
+	selected   int
+	input      textinput.Model
+	chosen     *note.Note
+}
+
+// PickNote lets the user choose one of candidates, filtering them by fuzzy match.
+// The picker is drawn on stderr so stdout stays usable for the command output.
+func PickNote(query string, candidates []*note.Note) (*note.Note, error) {
+	initStyles()
+
+	ti := textinput.New()
+	ti.Prompt = "絞り込み: "
+	ti.Focus()
+
+	m := pickerModel{
+		query:      query,
+		candidates: candidates,
+		filtered:   candidates,
+		input:      ti,
+	}
+
+	result, err := tea.NewProgram(m, tea.WithOutput(os.Stderr)).Run()
+	if err != nil {
+		return nil, err
+	}
+	if chosen := result.(pickerModel).chosen; chosen != nil {
+		return chosen, nil
+	}
+	return nil, ErrPickCanceled
+}
+
+func (m pickerModel) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+func (m pickerModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch msg.String() {
+		case "enter":
+			if m.selected < len(m.filtered) {
+				m.chosen = m.filtered[m.selected]
+			}
+			return m, tea.Quit
+		case "esc", "ctrl+c":
+			return m, tea.Quit
+		case "down", "ctrl+n", "ctrl+j", "tab":
+			if m.selected < len(m.filtered)-1 {
+				m.selected++
+			}
+			return m, nil
+		case "up", "ctrl+p", "ctrl+k", "shift+tab":
+			if m.selected > 0 {
+				m.selected--
+			}
+			return m, nil
+		}
+	}
+
+	var cmd tea.Cmd
+	prev := m.input.Value()
+	m.input, cmd = m.input.Update(msg)
+	if m.input.Value() != prev {
+		m.filter()
+	}
+	return m, cmd
+}
+
+func (m *pickerModel) filter() {
+	pattern := m.input.Value()
+	type scored struct {
+		n     *note.Note
+		score int
+	}
+	var matches []scored
+	for _, n := range m.candidates {
+		if score, _, ok := util.FuzzyMatch(pattern, pickerLabel(n)); ok {
+			matches = append(matches, scored{n, score})
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].score > matches[j].score
+	})
+
+	m.filtered = make([]*note.Note, len(matches))
+	for i, s := range matches {
+		m.filtered[i] = s.n
+	}
+	m.selected = 0
+}
+
+// pickerLabel shows the directory too, since candidates often share a title.
+func pickerLabel(n *note.Note) string {
+	if dir := filepath.Dir(n.ID); dir != "." {
+		return dir + "/" + n.Title
+	}
+	return n.Title
+}
+
+func (m pickerModel) View() string {
+	if m.chosen != nil {
+		return ""
+	}
+	symbols := config.Global.Theme.Symbols
+	dateFormat := config.Global.Formats.DateTime
+
+	var b strings.Builder
+	b.WriteString(styles.Title.Render(fmt.Sprintf("「%s」に該当するメモが %d 件あります", m.query, len(m.candidates))))
+	b.WriteString("\n")
+	b.WriteString(m.input.View())
+	b.WriteString("\n")
+
+	start := 0
+	if m.selected >= pickerMaxItems {
+		start = m.selected - pickerMaxItems + 1
+	}
+	end := start + pickerMaxItems
+	if end > len(m.filtered) {
+		end = len(m.filtered)
+	}
+
+	if len(m.filtered) == 0 {
+		b.WriteString(styles.Meta.Render("  該当なし"))
+		b.WriteString("\n")
+	}
+	for i := start; i < end; i++ {
+		n := m.filtered[i]
+		prefix := symbols.CursorEmpty
+		style := styles.Normal
+		if i == m.selected {
+			prefix = symbols.Cursor
+			style = styles.Selected
+		}
+		b.WriteString(style.Render(prefix + pickerLabel(n)))
+		b.WriteString(styles.Meta.Render("  " + n.Modified.Format(dateFormat)))
+		b.WriteString("\n")
+	}
+
+	b.WriteString(styles.Help.Render("↑/↓: 移動 | Enter: 決定 | Esc: キャンセル"))
+	b.WriteString("\n")
+	return b.String()
+}
@@ -0,0 +1,274 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/intiramisu/note-cli/internal/config"
+	"github.com/intiramisu/note-cli/internal/note"
+	"github.com/intiramisu/note-cli/internal/util"
+)
+
+// リーダーのヘッダー (タイトル+余白+メタ情報) とフッター (余白+ヘルプ) の行数
+const (
+	readerHeaderHeight = 3
+	readerFooterHeight = 2
+)
+
+// プレビューのヘッダー (タイトル+メタ情報+空行) の行数
+const previewHeaderHeight = 3
+
+// renderContent renders the note's markdown to width, caching the result
+// until the note is modified.
+func (m model) renderContent(n *note.Note, width int) []string {
+	key := fmt.Sprintf("%s|%d|%d", n.ID, width, n.Modified.UnixNano())
+	if cached, ok := m.renderCache[key]; ok {
+		return strings.Split(cached, "\n")
+	}
+
+	rendered, err := util.RenderMarkdown(n.Content, width, config.Global.Display.MarkdownStyle)
+	if err != nil {
+		var lines []string
+		for _, line := range strings.Split(n.Content, "\n") {
+			lines = append(lines, util.TruncateString(line, width))
+		}
+		rendered = strings.Join(lines, "\n")
+	}
+	rendered = strings.TrimRight(rendered, "\n")
+	m.renderCache[key] = rendered
+	return strings.Split(rendered, "\n")
+}
+
+// previewVisible reports whether the note list is drawn with a preview pane.
+func (m model) previewVisible() bool {
+	return m.showPreview && m.width >= config.Global.Display.PreviewWidth
+}
+
+// previewWidths splits the screen between the note list and the preview pane.
+func (m model) previewWidths() (listWidth, previewWidth int) {
+	listWidth = max(m.width*2/5, 30)
+	return listWidth, m.width - listWidth - 3
+}
+
+func (m model) previewHeight() int {
+	return m.height - 3
+}
+
+// renderPreview draws the selected note in the right-hand pane of the note
+// list, from the line it was scrolled to.
+func (m model) renderPreview(width, height int) string {
+	if m.selectedNote < 0 || m.selectedNote >= len(m.notes) {
+		return ""
+	}
+	n := m.notes[m.selectedNote]
+	formats := config.Global.Formats
+
+	var lines []string
+	lines = append(lines, styles.Selected.Render(util.TruncateString(n.Title, width)))
+	meta := n.Modified.Format(formats.DateTime)
+	if len(n.Tags) > 0 {
+		meta += " | " + strings.Join(n.Tags, ", ")
+	}
+	lines = append(lines, styles.Meta.Render(util.TruncateString(meta, width)), "")
+
+	content := m.renderContent(n, width)
+	if m.previewID == n.ID {
+		content = content[min(m.previewOffset, len(content)):]
+	}
+	room := height - previewHeaderHeight
+	if room < 2 {
+		content = nil
+	} else if len(content) > room {
+		content = content[:room-1]
+		content = append(content, styles.Meta.Render(fmt.Sprintf("... (%s: 全文表示)", util.HelpKeys(m.keys.Reader))))
+	}
+	lines = append(lines, content...)
+
+	return lipgloss.NewStyle().MaxWidth(width).Render(strings.Join(lines, "\n"))
+}
+
+// openReader shows the selected note full screen; esc returns to the current mode.
+func (m *model) openReader() {
+	if m.selectedNote < 0 || m.selectedNote >= len(m.notes) {
+		return
+	}
+	m.readerReturn = m.mode
+	m.mode = modeReader
+	m.searchInput.Reset()
+	m.searchHits = nil
+	m.searchIndex = 0
+	m.reader = viewport.New(m.width, m.readerHeight())
+	m.reader.KeyMap.Up = m.keys.Up
+	m.reader.KeyMap.Down = m.keys.Down
+	m.reader.KeyMap.PageUp = m.keys.PageUp
+	m.reader.KeyMap.PageDown = m.keys.PageDown
+	m.setReaderContent()
+}
+
+func (m model) readerHeight() int {
+	h := m.height - readerHeaderHeight - readerFooterHeight
+	if h < 1 {
+		h = 1
+	}
+	return h
+}
+
+// setReaderContent (re)renders the note into the viewport, highlighting search hits.
+func (m *model) setReaderContent() {
+	n := m.notes[m.selectedNote]
+	lines := m.renderContent(n, m.width-2)
+
+	query := m.searchInput.Value()
+	m.searchHits = nil
+	if query != "" {
+		for i, line := range lines {
+			plain := ansi.Strip(line)
+			positions := matchPositions(plain, query)
+			if len(positions) == 0 {
+				continue
+			}
+			base := styles.Normal
+			if len(m.searchHits) == m.searchIndex {
+				base = styles.Selected
+			}
+			m.searchHits = append(m.searchHits, i)
+			lines[i], _ = highlight(plain, positions, m.width, base, styles.Match)
+		}
+	}
+	m.reader.SetContent(strings.Join(lines, "\n"))
+}
+
+// jumpToHit scrolls so that the current search hit is visible.
+func (m *model) jumpToHit() {
+	if len(m.searchHits) == 0 {
+		return
+	}
+	m.setReaderContent()
+	line := m.searchHits[m.searchIndex]
+	if line < m.reader.YOffset || line >= m.reader.YOffset+m.reader.Height {
+		m.reader.SetYOffset(line - m.reader.Height/3)
+	}
+}
+
+func (m model) handleReader(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	k := m.keys
+	if m.searching {
+		switch {
+		case key.Matches(msg, k.Confirm):
+			m.searching = false
+			m.searchInput.Blur()
+			m.searchIndex = 0
+			m.setReaderContent()
+			// 表示位置以降の最初の一致へ
+			for i, line := range m.searchHits {
+				if line >= m.reader.YOffset {
+					m.searchIndex = i
+					break
+				}
+			}
+			m.jumpToHit()
+			return m, nil
+		case key.Matches(msg, k.Cancel):
+			m.searching = false
+			m.searchInput.Blur()
+			return m, nil
+		}
+		var cmd tea.Cmd
+		m.searchInput, cmd = m.searchInput.Update(msg)
+		return m, cmd
+	}
+
+	switch {
+	case key.Matches(msg, k.ForceQuit):
+		return m, tea.Quit
+
+	case key.Matches(msg, k.ClearSearch) && m.searchInput.Value() != "":
+		m.searchInput.Reset()
+		m.setReaderContent()
+		return m, nil
+
+	case key.Matches(msg, k.Close):
+		m.mode = m.readerReturn
+		if m.mode == modeNoteDetail {
+			m.loadRelatedTasks()
+		}
+		return m, nil
+
+	case key.Matches(msg, k.Search):
+		m.searching = true
+		m.searchInput.Reset()
+		m.searchInput.Focus()
+		return m, textinput.Blink
+
+	case key.Matches(msg, k.NextMatch):
+		if len(m.searchHits) > 0 {
+			m.searchIndex = (m.searchIndex + 1) % len(m.searchHits)
+			m.jumpToHit()
+		}
+		return m, nil
+
+	case key.Matches(msg, k.PrevMatch):
+		if len(m.searchHits) > 0 {
+			m.searchIndex = (m.searchIndex - 1 + len(m.searchHits)) % len(m.searchHits)
+			m.jumpToHit()
+		}
+		return m, nil
+
+	case key.Matches(msg, k.Top):
+		m.reader.GotoTop()
+		return m, nil
+
+	case key.Matches(msg, k.Bottom):
+		m.reader.GotoBottom()
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.reader, cmd = m.reader.Update(msg)
+	return m, cmd
+}
+
+func (m model) renderReader() string {
+	n := m.notes[m.selectedNote]
+	formats := config.Global.Formats
+
+	var b strings.Builder
+	b.WriteString(styles.Title.Render(config.Global.Theme.Symbols.NoteIcon + " " + n.Title))
+	b.WriteString("\n")
+
+	status := fmt.Sprintf("更新: %s | %d%%", n.Modified.Format(formats.DateTime), int(m.reader.ScrollPercent()*100))
+	if query := m.searchInput.Value(); query != "" && !m.searching {
+		if len(m.searchHits) == 0 {
+			status += fmt.Sprintf(" | 「%s」は見つかりません", query)
+		} else {
+			status += fmt.Sprintf(" | 「%s」 %d/%d", query, m.searchIndex+1, len(m.searchHits))
+		}
+	}
+	b.WriteString(styles.Meta.Render(status))
+	b.WriteString("\n")
+
+	b.WriteString(m.reader.View())
+	b.WriteString("\n")
+
+	if m.searching {
+		b.WriteString("\n")
+		b.WriteString(m.searchInput.View())
+	} else {
+		k := m.keys
+		b.WriteString(styles.Help.Render(helpLine(
+			helpItem("スクロール", k.Down, k.Up),
+			helpItem("", k.PageDown, k.PageUp),
+			helpItem("", k.Top, k.Bottom),
+			helpItem("", k.Search),
+			helpItem("", k.NextMatch, k.PrevMatch),
+			helpItem("戻る", k.Close),
+		)))
+	}
+	return b.String()
+}
@@ -0,0 +1,71 @@
+package ui
+
+import (
+	"github.com/intiramisu/note-cli/internal/note"
+	"github.com/intiramisu/note-cli/internal/task"
+)
+
+// reloadFiles picks up notes and tasks changed by another process, keeping
+// the selected note, task and link when they still exist.
+func (m *model) reloadFiles() {
+	noteID := m.selectedNoteID()
+	taskID := taskIDAt(m.tasks, m.selectedTask)
+	unlinkedID := taskIDAt(m.unlinkedTasks, m.selectedUnlinked)
+
+	if err := m.taskManager.Reload(); err != nil {
+		m.status = err.Error()
+	}
+	m.reloadNotes()
+
+	switch m.mode {
+	case modeNoteDetail, modeLinks, modeAttachTask, modeReader:
+		if m.selectedNoteID() != noteID {
+			m.mode = modeNotesList
+			m.status = "表示中のメモが削除されました: " + noteID
+			return
+		}
+	case modeTags:
+		m.tagCounts = note.CountTags(m.allNotes, note.SortTagsByName)
+		m.selectedTag = max(min(m.selectedTag, len(m.tagCounts)-1), 0)
+		return
+	default:
+		return
+	}
+
+	m.loadRelatedTasks()
+	m.selectedTask = taskIndex(m.tasks, taskID, m.selectedTask)
+
+	switch m.mode {
+	case modeLinks:
+		selected := m.selectedLink
+		m.loadLinks()
+		if len(m.links) == 0 {
+			m.mode = modeNoteDetail
+		}
+		m.selectedLink = max(min(selected, len(m.links)-1), 0)
+	case modeNoteDetail:
+		m.loadLinks()
+	case modeAttachTask:
+		m.loadUnlinkedTasks()
+		m.selectedUnlinked = taskIndex(m.unlinkedTasks, unlinkedID, m.selectedUnlinked)
+	case modeReader:
+		m.setReaderContent()
+	}
+}
+
+func taskIDAt(tasks []*task.Task, i int) int {
+	if i >= 0 && i < len(tasks) {
+		return tasks[i].ID
+	}
+	return 0
+}
+
+// taskIndex finds id in tasks, falling back to fallback kept within range.
+func taskIndex(tasks []*task.Task, id, fallback int) int {
+	for i, t := range tasks {
+		if t.ID == id {
+			return i
+		}
+	}
+	return max(min(fallback, len(tasks)-1), 0)
+}
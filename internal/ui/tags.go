@@ -0,0 +1,163 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/intiramisu/note-cli/internal/config"
+	"github.com/intiramisu/note-cli/internal/note"
+	"github.com/intiramisu/note-cli/internal/util"
+	"github.com/mattn/go-runewidth"
+)
+
+const tagPanelMaxWidth = 30
+
+// openTags focuses the tag panel next to the note list. Tags are sorted by
+// name so nested tags follow their parent.
+func (m *model) openTags() {
+	m.tagCounts = note.CountTags(m.allNotes, note.SortTagsByName)
+	if m.selectedTag >= len(m.tagCounts) {
+		m.selectedTag = 0
+	}
+	m.mode = modeTags
+}
+
+func (m model) tagSelected(tag string) bool {
+	for _, t := range m.filterTags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *model) toggleTag(tag string) {
+	for i, t := range m.filterTags {
+		if t == tag {
+			m.filterTags = append(m.filterTags[:i:i], m.filterTags[i+1:]...)
+			return
+		}
+	}
+	m.filterTags = append(m.filterTags, tag)
+}
+
+func (m *model) clearTagFilter() {
+	id := m.selectedNoteID()
+	m.filterTags = nil
+	m.applyFilter()
+	m.selectNoteByID(id)
+}
+
+func (m model) handleTags(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	k := m.keys
+	switch {
+	case key.Matches(msg, k.ForceQuit):
+		return m, tea.Quit
+
+	case key.Matches(msg, k.Down):
+		if m.selectedTag < len(m.tagCounts)-1 {
+			m.selectedTag++
+		}
+
+	case key.Matches(msg, k.Up):
+		if m.selectedTag > 0 {
+			m.selectedTag--
+		}
+
+	case key.Matches(msg, k.ToggleTag):
+		if m.selectedTag < len(m.tagCounts) {
+			m.toggleTag(m.tagCounts[m.selectedTag].Name)
+			m.applyFilter()
+			m.selectedNote = 0
+		}
+
+	case key.Matches(msg, k.TagMatchAll):
+		m.tagMatchAll = !m.tagMatchAll
+		m.applyFilter()
+		m.selectedNote = 0
+
+	case key.Matches(msg, k.ClearTags):
+		m.clearTagFilter()
+
+	case key.Matches(msg, k.CloseTags):
+		m.mode = modeNotesList
+	}
+
+	return m, nil
+}
+
+// tagFilterLabel describes the selected tags, e.g. "work AND go".
+func (m model) tagFilterLabel() string {
+	op := " OR "
+	if m.tagMatchAll {
+		op = " AND "
+	}
+	return strings.Join(m.filterTags, op)
+}
+
+// renderTagPanel draws the tag list with counts; selected tags are checked.
+func (m model) renderTagPanel(width, height int) string {
+	symbols := config.Global.Theme.Symbols
+
+	var b strings.Builder
+	mode := "OR"
+	if m.tagMatchAll {
+		mode = "AND"
+	}
+	b.WriteString(styles.Title.Render(fmt.Sprintf("Tags (%s)", mode)))
+	b.WriteString("\n\n")
+
+	if len(m.tagCounts) == 0 {
+		b.WriteString(styles.Meta.Render("タグがありません"))
+		return b.String()
+	}
+
+	maxItems := height - 3
+	if maxItems < 1 {
+		maxItems = 1
+	}
+	start := 0
+	if m.selectedTag >= maxItems {
+		start = m.selectedTag - maxItems + 1
+	}
+	end := min(start+maxItems, len(m.tagCounts))
+
+	for i := start; i < end; i++ {
+		tc := m.tagCounts[i]
+		prefix := symbols.CursorEmpty
+		style := styles.Normal
+		if i == m.selectedTag {
+			prefix = symbols.Cursor
+			style = styles.Selected
+		}
+		check := "[ ] "
+		if m.tagSelected(tc.Name) {
+			check = "[x] "
+		}
+		count := fmt.Sprintf(" %d", tc.Count)
+		nameWidth := width - runewidth.StringWidth(prefix+check+count)
+		name := util.TruncateString(tagTreeLabel(tc.Name), nameWidth)
+		padding := strings.Repeat(" ", max(nameWidth-runewidth.StringWidth(name), 0))
+		b.WriteString(style.Render(prefix + check + name + padding + count))
+		b.WriteString("\n")
+	}
+	return strings.TrimRight(b.String(), "\n")
+}
+
+// tagTreeLabel shows a nested tag as its last segment, indented by depth.
+func tagTreeLabel(tag string) string {
+	parts := strings.Split(tag, util.TagSeparator)
+	return strings.Repeat("  ", len(parts)-1) + parts[len(parts)-1]
+}
+
+// tagPanelWidth fits the longest tag, within tagPanelMaxWidth.
+func (m model) tagPanelWidth() int {
+	width := 16
+	for _, tc := range m.tagCounts {
+		w := runewidth.StringWidth(fmt.Sprintf("%s[x] %s %d", config.Global.Theme.Symbols.Cursor, tagTreeLabel(tc.Name), tc.Count))
+		width = max(width, w)
+	}
+	return min(width, tagPanelMaxWidth)
+}
@@ -0,0 +1,221 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/intiramisu/note-cli/internal/task"
+	"github.com/intiramisu/note-cli/internal/util"
+)
+
+// targetTaskIDs returns the marked related tasks, or the selected one when
+// nothing is marked.
+func (m *model) targetTaskIDs() []int {
+	if m.taskSel.Active() {
+		return m.taskSel.IDs(m.tasks, m.tasks, m.selectedTask)
+	}
+	if id := taskIDAt(m.tasks, m.selectedTask); id != 0 {
+		return []int{id}
+	}
+	return nil
+}
+
+// markTask marks or unmarks the selected task and moves to the next one.
+func (m *model) markTask() {
+	if id := taskIDAt(m.tasks, m.selectedTask); id != 0 {
+		m.taskSel.Toggle(id)
+		m.moveDown()
+	}
+}
+
+func (m *model) toggleVisualTasks() {
+	if m.taskSel.Visual() {
+		m.taskSel.EndVisual(m.tasks, m.selectedTask)
+		return
+	}
+	if len(m.tasks) > 0 {
+		m.taskSel.StartVisual(m.selectedTask)
+	}
+}
+
+// applyTasks runs fn on ids, clears the selection and keeps the cursor on
+// the selected task when it is still related to the note.
+func (m *model) applyTasks(ids []int, fn func(ids []int) error) {
+	if len(ids) == 0 {
+		return
+	}
+	cursorID := taskIDAt(m.tasks, m.selectedTask)
+	if err := fn(ids); err != nil {
+		m.status = err.Error()
+		return
+	}
+	m.taskSel.Clear()
+	m.loadRelatedTasks()
+	m.selectedTask = taskIndex(m.tasks, cursorID, m.selectedTask)
+}
+
+func (m *model) toggleTasks() {
+	m.applyTasks(m.targetTaskIDs(), m.taskManager.ToggleAll)
+}
+
+func (m *model) unlinkTasks() {
+	m.applyTasks(m.targetTaskIDs(), func(ids []int) error {
+		return m.taskManager.UpdateAll(ids, func(t *task.Task) { t.NoteID = "" })
+	})
+}
+
+func (m *model) setTaskPriority(p task.Priority) {
+	m.applyTasks(m.targetTaskIDs(), func(ids []int) error {
+		return m.taskManager.UpdateAll(ids, func(t *task.Task) { t.Priority = p })
+	})
+}
+
+// deleteTasks deletes the selected task, or asks before deleting several.
+func (m *model) deleteTasks() {
+	ids := m.targetTaskIDs()
+	if len(ids) > 1 {
+		m.confirmTasks = ids
+		return
+	}
+	m.applyTasks(ids, m.taskManager.DeleteAll)
+}
+
+func (m model) handleConfirmTasks(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, m.keys.Yes):
+		m.applyTasks(m.confirmTasks, m.taskManager.DeleteAll)
+		m.confirmTasks = nil
+	case key.Matches(msg, m.keys.No):
+		m.confirmTasks = nil
+	}
+	return m, nil
+}
+
+// startTaskDue prompts for the due date of the target tasks, filled in with
+// the current one when a single task is chosen.
+func (m *model) startTaskDue() tea.Cmd {
+	ids := m.targetTaskIDs()
+	if len(ids) == 0 {
+		return nil
+	}
+	m.dueTasks = ids
+	m.dueInput.Reset()
+	if len(ids) == 1 {
+		if t, err := m.taskManager.Get(ids[0]); err == nil && t.HasDueDate() {
+			m.dueInput.SetValue(t.DueDate.Format("2006-01-02"))
+		}
+	}
+	m.dueInput.Focus()
+	return textinput.Blink
+}
+
+func (m *model) endTaskDue() {
+	m.dueTasks = nil
+	m.dueInput.Reset()
+	m.dueInput.Blur()
+}
+
+// handleTaskDue sets the entered due date; an empty value clears it.
+func (m model) handleTaskDue(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, m.keys.Confirm):
+		value := strings.TrimSpace(m.dueInput.Value())
+		due, err := util.ParseDueDate(value)
+		if err != nil {
+			m.status = "無効な日付です: " + value
+			return m, nil
+		}
+		m.status = ""
+		m.applyTasks(m.dueTasks, func(ids []int) error {
+			return m.taskManager.UpdateAll(ids, func(t *task.Task) { t.DueDate = due })
+		})
+		m.endTaskDue()
+		return m, nil
+
+	case key.Matches(msg, m.keys.Cancel):
+		m.endTaskDue()
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.dueInput, cmd = m.dueInput.Update(msg)
+	return m, cmd
+}
+
+// startMoveTasks lists the other notes in the palette to move the target
+// tasks to one of them.
+func (m *model) startMoveTasks() tea.Cmd {
+	ids := m.targetTaskIDs()
+	if len(ids) == 0 {
+		return nil
+	}
+	current := m.selectedNoteID()
+	var items []util.PaletteItem
+	m.moveNotes = nil
+	for _, n := range m.allNotes {
+		if n.ID == current {
+			continue
+		}
+		items = append(items, util.PaletteItem{Name: n.Title, Keywords: n.ID, Key: n.ID})
+		m.moveNotes = append(m.moveNotes, n.ID)
+	}
+	m.moveTasks = ids
+	m.paletteOpen = true
+	return m.palette.OpenList(fmt.Sprintf("移動先のメモ (%d 件のタスク)", len(ids)), items)
+}
+
+// finishMoveTasks links the tasks chosen in startMoveTasks to the chosen note.
+func (m *model) finishMoveTasks(chosen int) {
+	ids := m.moveTasks
+	m.moveTasks = nil
+	if chosen < 0 {
+		return
+	}
+	noteID := m.moveNotes[chosen]
+	m.applyTasks(ids, func(ids []int) error {
+		if err := m.taskManager.UpdateAll(ids, func(t *task.Task) { t.NoteID = noteID }); err != nil {
+			return err
+		}
+		m.status = fmt.Sprintf("%d 件のタスクを移動しました: %s", len(ids), noteID)
+		return nil
+	})
+}
+
+// attachTasks links the marked unlinked tasks (or the selected one) to the
+// current note.
+func (m *model) attachTasks() {
+	ids := m.attachSel.IDs(m.unlinkedTasks, m.unlinkedTasks, m.selectedUnlinked)
+	if len(ids) == 0 {
+		if id := taskIDAt(m.unlinkedTasks, m.selectedUnlinked); id != 0 {
+			ids = []int{id}
+		}
+	}
+	noteID := m.selectedNoteID()
+	m.applyTasks(ids, func(ids []int) error {
+		return m.taskManager.UpdateAll(ids, func(t *task.Task) { t.NoteID = noteID })
+	})
+	m.attachSel.Clear()
+}
+
+// renderTaskSelection draws the delete confirmation, the due date prompt or
+// the number of marked tasks below the related tasks.
+func (m model) renderTaskSelection() string {
+	switch {
+	case m.confirmTasks != nil:
+		return styles.Selected.Render(fmt.Sprintf("%d 件のタスクを削除しますか? %s", len(m.confirmTasks), util.YesNo(m.keys.Yes, m.keys.No))) + "\n"
+	case m.dueTasks != nil:
+		k := m.keys
+		return fmt.Sprintf("  期限 (%d 件, 空欄で解除): %s\n", len(m.dueTasks), m.dueInput.View()) +
+			styles.Meta.Render("  "+helpLine(helpItem("", k.Confirm), helpItem("", k.Cancel))) + "\n"
+	case m.taskSel.Active():
+		label := fmt.Sprintf("%d 件を選択中", len(m.targetTaskIDs()))
+		if m.taskSel.Visual() {
+			label += " (範囲選択)"
+		}
+		return styles.Marked.Render(label) + "\n"
+	}
+	return ""
+}
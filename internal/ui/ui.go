@@ -2,11 +2,12 @@ package ui
 
 import (
 	"fmt"
-	"path/filepath"
 	"strings"
 	"time"
 
+	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/intiramisu/note-cli/internal/config"
@@ -28,6 +29,12 @@ const (
 	modeNotesList viewMode = iota
 	modeNoteDetail
 	modeAttachTask
+	modeInbox
+	modeInboxMove
+	modeReader
+	modeLinks
+	modeTags
+	modeBoard
 )
 
 type model struct {
@@ -35,7 +42,8 @@ type model struct {
 	taskManager *task.Manager
 
 	mode         viewMode
-	notes        []*note.Note
+	allNotes     []*note.Note
+	notes        []*note.Note // 絞り込み後の一覧
 	selectedNote int
 	tasks        []*task.Task
 	selectedTask int
@@ -59,12 +67,85 @@ type model struct {
 	// タスク紐づけ用
 	unlinkedTasks    []*task.Task
 	selectedUnlinked int
+	attachSel        task.Selection
+
+	// 関連タスクの複数選択・一括操作
+	taskSel      task.Selection
+	confirmTasks []int    // 削除確認中のタスク
+	dueTasks     []int    // 期限入力中のタスク
+	moveTasks    []int    // 移動先のメモを選択中のタスク
+	moveNotes    []string // 移動先の候補
+
+	// インボックス処理用
+	inboxID        string
+	inboxEntries   []string
+	selectedInbox  int
+	moveTarget     int
+	inboxStatus    string
+	confirmDiscard bool // 選択中の項目の破棄を確認中
+
+	// 絞り込み用
+	filtering   bool
+	filterInput textinput.Model
+	fullText    bool
+	noteMatches map[string]noteMatch
+
+	// プレビュー・全文表示用
+	showPreview   bool
+	previewID     string // previewOffset を適用するメモ
+	previewOffset int    // ホイールでスクロールしたプレビューの行
+	renderCache   map[string]string
+	reader        viewport.Model
+	readerReturn  viewMode
+	searching     bool
+	searchInput   textinput.Model
+	searchHits    []int // 一致した行番号
+	searchIndex   int
+
+	// リンク移動用
+	links         []linkEntry
+	selectedLink  int
+	history       []string // 戻る用のメモID
+	future        []string // 進む用のメモID
+	pendingCreate string   // 作成確認中のリンク名
+
+	// メモの作成・名前変更・削除用
+	prompt           promptKind
+	promptInput      textinput.Model
+	newTitle         string
+	templates        []string // 先頭の "" はテンプレートなし
+	selectedTemplate int
+	pickingTemplate  bool
+	confirmDelete    bool
+
+	// タグ絞り込み用
+	tagCounts   []note.TagCount
+	selectedTag int
+	filterTags  []string
+	tagMatchAll bool // true: AND, false: OR
+
+	// ヘルプ・コマンドパレット・タスクボード
+	showHelp    bool
+	help        util.HelpView
+	paletteOpen bool
+	palette     util.Palette
+	actions     []paletteAction
+	board       task.Model
+	boardReturn viewMode
+
+	status string
+	keys   keyMap
 }
 
-func NewModel(noteStorage *note.Storage, taskManager *task.Manager) model {
+func NewModel(noteStorage *note.Storage, taskManager *task.Manager) (model, error) {
 	initStyles()
 	cfg := config.Global
 
+	keys, err := newKeyMap(cfg.Keys.UI)
+	if err != nil {
+		return model{}, fmt.Errorf("keys.ui: %w", err)
+	}
+
 	ti := textinput.New()
 	ti.CharLimit = cfg.Display.TaskCharLimit
 	ti.Width = cfg.Display.InputWidth
@@ -75,6 +156,18 @@ func NewModel(noteStorage *note.Storage, taskManager *task.Manager) model {
 	di.Width = 30
 	di.SetValue("")
 
+	fi := textinput.New()
+	fi.Prompt = "/"
+	fi.Width = cfg.Display.InputWidth
+
+	si := textinput.New()
+	si.Prompt = "検索: "
+	si.Width = cfg.Display.InputWidth
+
+	pi := textinput.New()
+	pi.Prompt = ""
+	pi.Width = cfg.Display.InputWidth
+
 	return model{
 		noteStorage:  noteStorage,
 		taskManager:  taskManager,
@@ -82,7 +175,14 @@ func NewModel(noteStorage *note.Storage, taskManager *task.Manager) model {
 		taskInput:    ti,
 		taskPriority: task.PriorityMedium,
 		dueInput:     di,
-	}
+		filterInput:  fi,
+		showPreview:  cfg.Display.Preview,
+		renderCache:  make(map[string]string),
+		searchInput:  si,
+		promptInput:  pi,
+		palette:      util.NewPalette(cfg.Display.InputWidth, keys.paletteKeys()),
+		keys:         keys,
+	}, nil
 }
 
 func (m model) Init() tea.Cmd {
@@ -106,30 +206,93 @@ type errMsg struct {
 }
 
 func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if m.mode == modeBoard {
+		return m.updateBoard(msg)
+	}
+
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		if m.showHelp {
+			return m.handleHelp(msg)
+		}
+		if m.paletteOpen {
+			return m.handlePalette(msg)
+		}
+		if !m.inputActive() {
+			switch {
+			case key.Matches(msg, m.keys.Help):
+				m.openHelp()
+				return m, nil
+			case key.Matches(msg, m.keys.Palette) && (m.mode == modeNotesList || m.mode == modeNoteDetail):
+				m.status = ""
+				return m, m.openPalette()
+			}
+		}
 		if m.addingTask {
 			return m.handleTaskInput(msg)
 		}
+		if m.confirmTasks != nil {
+			return m.handleConfirmTasks(msg)
+		}
+		if m.dueTasks != nil {
+			return m.handleTaskDue(msg)
+		}
 		if m.mode == modeAttachTask {
 			return m.handleAttachTask(msg)
 		}
+		if m.mode == modeInbox || m.mode == modeInboxMove {
+			return m.handleInbox(msg)
+		}
+		if m.mode == modeReader {
+			return m.handleReader(msg)
+		}
+		if m.mode == modeLinks {
+			return m.handleLinks(msg)
+		}
+		if m.mode == modeTags {
+			return m.handleTags(msg)
+		}
+		if m.noteEditing() {
+			return m.handleNoteEdit(msg)
+		}
+		if m.filtering {
+			return m.handleFilterInput(msg)
+		}
 		return m.handleKeyPress(msg)
 
+	case tea.MouseMsg:
+		return m.handleMouse(msg)
+
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
+		m.help.SetSize(m.width, m.height)
+		if m.mode == modeReader {
+			m.reader.Width = m.width
+			m.reader.Height = m.readerHeight()
+			m.setReaderContent()
+		}
 		return m, nil
 
 	case notesLoadedMsg:
-		m.notes = msg.notes
+		m.setNotes(msg.notes)
+		return m, nil
+
+	case util.FilesChangedMsg:
+		m.reloadFiles()
 		return m, nil
 
+	case editorFinishedMsg:
+		return m.handleEditorFinished(msg)
+
 	case errMsg:
 		return m, tea.Quit
 	}
 
 	// Forward other messages (cursor blink etc.) to active text input
+	if m.paletteOpen {
+		return m.handlePalette(msg)
+	}
 	if m.addingTask {
 		var cmd tea.Cmd
 		if m.settingDue {
@@ -139,88 +302,192 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		return m, cmd
 	}
+	if m.dueTasks != nil {
+		var cmd tea.Cmd
+		m.dueInput, cmd = m.dueInput.Update(msg)
+		return m, cmd
+	}
+	if m.filtering {
+		var cmd tea.Cmd
+		m.filterInput, cmd = m.filterInput.Update(msg)
+		return m, cmd
+	}
+	if m.searching {
+		var cmd tea.Cmd
+		m.searchInput, cmd = m.searchInput.Update(msg)
+		return m, cmd
+	}
+	if m.prompt != promptNone {
+		var cmd tea.Cmd
+		m.promptInput, cmd = m.promptInput.Update(msg)
+		return m, cmd
+	}
 
 	return m, nil
 }
 
+// inputActive reports whether a text input or confirmation has the keyboard.
+func (m model) inputActive() bool {
+	return m.addingTask || m.filtering || m.searching || m.noteEditing() || m.pendingCreate != "" ||
+		m.confirmTasks != nil || m.dueTasks != nil || m.confirmDiscard
+}
+
 func (m model) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
-	case "q", "ctrl+c":
+	m.status = ""
+	k := m.keys
+
+	switch {
+	case key.Matches(msg, k.Quit):
 		return m, tea.Quit
 
-	case "j", "down":
+	case key.Matches(msg, k.Down):
 		m.moveDown()
+		return m, nil
 
-	case "k", "up":
+	case key.Matches(msg, k.Up):
 		m.moveUp()
+		return m, nil
 
-	case "enter":
-		if m.mode == modeNotesList && len(m.notes) > 0 {
-			m.mode = modeNoteDetail
-			m.selectedTask = 0
-			m.loadRelatedTasks()
-		} else if m.mode == modeNoteDetail && len(m.tasks) > 0 {
-			m.toggleTask()
-		}
+	case key.Matches(msg, k.Reader):
+		m.openReader()
+		return m, nil
 
-	case " ":
-		if m.mode == modeNoteDetail && len(m.tasks) > 0 {
-			m.toggleTask()
-		}
+	case key.Matches(msg, k.NewNote):
+		return m, m.startPrompt(promptCreate, "")
 
-	case "tab":
-		if m.mode == modeNoteDetail {
-			m.mode = modeNotesList
+	case key.Matches(msg, k.EditNote):
+		if m.currentNote() != nil {
+			return m, m.editNote(m.selectedNoteID(), false)
 		}
+		return m, nil
 
-	case "esc":
-		if m.mode == modeNoteDetail {
-			m.mode = modeNotesList
+	case key.Matches(msg, k.RenameNote):
+		if m.currentNote() != nil {
+			return m, m.startPrompt(promptRename, m.currentNote().Title)
 		}
+		return m, nil
 
-	case "i":
-		if m.mode == modeNoteDetail {
-			m.addingTask = true
-			m.taskInput.Reset()
-			m.taskInput.Focus()
-			m.taskPriority = task.PriorityMedium
-			return m, textinput.Blink
+	case key.Matches(msg, k.DeleteNote):
+		if m.currentNote() != nil {
+			m.confirmDelete = true
 		}
+		return m, nil
+	}
 
-	case "d", "x":
-		if m.mode == modeNoteDetail && len(m.tasks) > 0 {
-			m.deleteTask()
-		}
+	if m.mode == modeNotesList {
+		return m.handleListKey(msg)
+	}
+	return m.handleDetailKey(msg)
+}
+
+func (m model) handleListKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	k := m.keys
 
-	case "o":
-		if m.mode == modeNoteDetail && len(m.tasks) > 0 {
-			m.unlinkTask()
+	switch {
+	case key.Matches(msg, k.Select):
+		if len(m.notes) > 0 {
+			m.history, m.future = nil, nil
+			m.showNote(m.selectedNoteID())
 		}
 
-	case "a":
-		if m.mode == modeNoteDetail {
-			m.loadUnlinkedTasks()
-			if len(m.unlinkedTasks) > 0 {
-				m.mode = modeAttachTask
-				m.selectedUnlinked = 0
-			}
+	case key.Matches(msg, k.ClearFilter):
+		if m.filterActive() {
+			m.clearFilter()
+		} else if len(m.filterTags) > 0 {
+			m.clearTagFilter()
 		}
 
-	case "s":
-		if m.mode == modeNoteDetail {
-			m.sortByDue = !m.sortByDue
-			m.loadRelatedTasks()
+	case key.Matches(msg, k.Filter):
+		return m, m.startFilter()
+
+	case key.Matches(msg, k.Preview):
+		m.showPreview = !m.showPreview
+
+	case key.Matches(msg, k.Tags):
+		m.openTags()
+
+	case key.Matches(msg, k.Inbox):
+		m.openInbox()
+	}
+
+	return m, nil
+}
+
+func (m model) handleDetailKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	k := m.keys
+
+	switch {
+	case key.Matches(msg, k.ToggleTask):
+		m.toggleTasks()
+
+	// 選択中の Esc は一覧に戻らず選択を解除する
+	case key.Matches(msg, k.UnmarkTask) && m.taskSel.Active():
+		m.taskSel.Clear()
+
+	case key.Matches(msg, k.Back):
+		m.taskSel.Clear()
+		m.mode = modeNotesList
+
+	case key.Matches(msg, k.Links):
+		if len(m.links) > 0 {
+			m.mode = modeLinks
 		}
+
+	case key.Matches(msg, k.HistoryBack):
+		m.historyBack()
+
+	case key.Matches(msg, k.HistoryForward):
+		m.historyForward()
+
+	case key.Matches(msg, k.AddTask):
+		return m, m.startAddTask()
+
+	case key.Matches(msg, k.DeleteTask):
+		m.deleteTasks()
+
+	case key.Matches(msg, k.UnlinkTask):
+		m.unlinkTasks()
+
+	case key.Matches(msg, k.MarkTask):
+		m.markTask()
+
+	case key.Matches(msg, k.VisualTask):
+		m.toggleVisualTasks()
+
+	case key.Matches(msg, k.PriorityHigh):
+		m.setTaskPriority(task.PriorityHigh)
+
+	case key.Matches(msg, k.PriorityMedium):
+		m.setTaskPriority(task.PriorityMedium)
+
+	case key.Matches(msg, k.PriorityLow):
+		m.setTaskPriority(task.PriorityLow)
+
+	case key.Matches(msg, k.DueTask):
+		return m, m.startTaskDue()
+
+	case key.Matches(msg, k.MoveTask):
+		return m, m.startMoveTasks()
+
+	case key.Matches(msg, k.AttachTask):
+		m.openAttachTask()
+
+	case key.Matches(msg, k.Sort):
+		m.taskSel.EndVisual(m.tasks, m.selectedTask)
+		m.sortByDue = !m.sortByDue
+		m.loadRelatedTasks()
 	}
 
 	return m, nil
 }
 
 func (m model) handleTaskInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	k := m.keys
+
 	// 期限入力モード
 	if m.settingDue {
-		switch msg.String() {
-		case "enter":
+		switch {
+		case key.Matches(msg, k.Confirm):
 			if m.dueInput.Value() != "" {
 				m.taskDue = util.ParseDueDateSimple(m.dueInput.Value())
 			}
@@ -232,7 +499,7 @@ func (m model) handleTaskInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			m.taskDue = time.Time{}
 			return m, nil
 
-		case "esc":
+		case key.Matches(msg, k.Cancel):
 			m.settingDue = false
 			m.dueInput.Reset()
 			m.taskInput.Focus()
@@ -245,8 +512,8 @@ func (m model) handleTaskInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	}
 
 	// タスク説明入力モード
-	switch msg.String() {
-	case "enter":
+	switch {
+	case key.Matches(msg, k.Confirm):
 		if m.taskInput.Value() != "" {
 			m.addTask()
 		}
@@ -255,21 +522,21 @@ func (m model) handleTaskInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		m.taskDue = time.Time{}
 		return m, nil
 
-	case "esc":
+	case key.Matches(msg, k.Cancel):
 		m.addingTask = false
 		m.taskInput.Reset()
 		m.taskDue = time.Time{}
 		return m, nil
 
-	case "tab":
+	case key.Matches(msg, k.NextPriority):
 		m.taskPriority = task.CyclePriority(m.taskPriority, false)
 		return m, nil
 
-	case "shift+tab":
+	case key.Matches(msg, k.PrevPriority):
 		m.taskPriority = task.CyclePriority(m.taskPriority, true)
 		return m, nil
 
-	case "ctrl+d":
+	case key.Matches(msg, k.SetDue):
 		if m.taskInput.Value() != "" {
 			m.settingDue = true
 			m.taskInput.Blur()
@@ -327,26 +594,12 @@ func (m *model) toggleTask() {
 	}
 }
 
-func (m *model) deleteTask() {
-	if m.selectedTask >= 0 && m.selectedTask < len(m.tasks) {
-		t := m.tasks[m.selectedTask]
-		m.taskManager.Delete(t.ID)
-		m.loadRelatedTasks()
-		if m.selectedTask >= len(m.tasks) && m.selectedTask > 0 {
-			m.selectedTask--
-		}
-	}
-}
-
-func (m *model) unlinkTask() {
-	if m.selectedTask >= 0 && m.selectedTask < len(m.tasks) {
-		t := m.tasks[m.selectedTask]
-		m.taskManager.UnlinkNote(t.ID)
-		m.loadRelatedTasks()
-		if m.selectedTask >= len(m.tasks) && m.selectedTask > 0 {
-			m.selectedTask--
-		}
-	}
+func (m *model) startAddTask() tea.Cmd {
+	m.addingTask = true
+	m.taskInput.Reset()
+	m.taskInput.Focus()
+	m.taskPriority = task.PriorityMedium
+	return textinput.Blink
 }
 
 func (m *model) addTask() {
@@ -368,28 +621,42 @@ func (m *model) loadUnlinkedTasks() {
 	}
 }
 
+func (m *model) openAttachTask() {
+	m.loadUnlinkedTasks()
+	if len(m.unlinkedTasks) > 0 {
+		m.mode = modeAttachTask
+		m.selectedUnlinked = 0
+		m.attachSel.Clear()
+	}
+}
+
 func (m model) handleAttachTask(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
-	case "j", "down":
+	k := m.keys
+
+	switch {
+	case key.Matches(msg, k.Down):
 		if m.selectedUnlinked < len(m.unlinkedTasks)-1 {
 			m.selectedUnlinked++
 		}
 
-	case "k", "up":
+	case key.Matches(msg, k.Up):
 		if m.selectedUnlinked > 0 {
 			m.selectedUnlinked--
 		}
 
-	case "enter":
-		if m.selectedUnlinked >= 0 && m.selectedUnlinked < len(m.unlinkedTasks) {
-			t := m.unlinkedTasks[m.selectedUnlinked]
-			noteID := m.notes[m.selectedNote].ID
-			m.taskManager.SetNoteID(t.ID, noteID)
-			m.loadRelatedTasks()
-			m.mode = modeNoteDetail
+	case key.Matches(msg, k.MarkTask):
+		if id := taskIDAt(m.unlinkedTasks, m.selectedUnlinked); id != 0 {
+			m.attachSel.Toggle(id)
+			if m.selectedUnlinked < len(m.unlinkedTasks)-1 {
+				m.selectedUnlinked++
+			}
 		}
 
-	case "esc", "q":
+	case key.Matches(msg, k.Select):
+		m.attachTasks()
+		m.mode = modeNoteDetail
+
+	case key.Matches(msg, k.Close):
 		m.mode = modeNoteDetail
 	}
 
@@ -400,44 +667,179 @@ func (m model) View() string {
 	if m.width == 0 {
 		return "Loading..."
 	}
+	if m.mode == modeBoard {
+		return m.board.View()
+	}
+	if m.showHelp {
+		return m.help.View(styles)
+	}
+	if m.paletteOpen {
+		return m.palette.View(styles, m.width, m.height)
+	}
 
 	switch m.mode {
-	case modeNotesList:
+	case modeNotesList, modeTags:
 		return m.renderNotesList()
-	case modeNoteDetail:
+	case modeNoteDetail, modeLinks:
 		return m.renderNoteDetail()
 	case modeAttachTask:
 		return m.renderAttachTask()
+	case modeInbox:
+		return m.renderInbox()
+	case modeInboxMove:
+		return m.renderInboxMove()
+	case modeReader:
+		return m.renderReader()
 	}
 	return ""
 }
 
 func (m model) renderNotesList() string {
+	k := m.keys
+	var help string
+	switch {
+	case m.noteEditing():
+		help = m.renderNoteEdit()
+	case m.mode == modeTags:
+		help = styles.Help.Render(helpLine(
+			helpItem("移動", k.Down, k.Up),
+			helpItem("", k.ToggleTag),
+			helpItem("", k.TagMatchAll),
+			helpItem("", k.ClearTags),
+			helpItem("", k.CloseTags),
+		))
+	case m.filtering:
+		fullTextLabel := "全文検索"
+		if m.fullText {
+			fullTextLabel = "タイトルのみ"
+		}
+		help = styles.Help.Render(helpLine(
+			helpItem("移動", k.FilterDown, k.FilterUp),
+			helpItem("確定", k.Confirm),
+			helpItem(fullTextLabel, k.FullText),
+			helpItem("解除", k.ClearFilter),
+		))
+	case m.filterActive() || len(m.filterTags) > 0:
+		help = styles.Help.Render(helpLine(
+			helpItem("移動", k.Down, k.Up),
+			helpItem("詳細", k.Select),
+			helpItem("", k.Reader),
+			helpItem("絞り込み編集", k.Filter),
+			helpItem("", k.Tags),
+			helpItem("", k.ClearFilter),
+			helpItem("", k.Help),
+			helpItem("", k.Quit),
+		))
+	default:
+		help = styles.Help.Render(helpLine(
+			helpItem("移動", k.Down, k.Up),
+			helpItem("詳細", k.Select),
+			helpItem("", k.NewNote, k.EditNote, k.RenameNote, k.DeleteNote),
+			helpItem("", k.Reader),
+			helpItem("", k.Preview),
+			helpItem("", k.Filter),
+			helpItem("", k.Tags),
+			helpItem("", k.Inbox),
+			helpItem("", k.Help),
+			helpItem("", k.Palette),
+			helpItem("", k.Quit),
+		))
+	}
+	if m.status != "" && !m.noteEditing() {
+		help = "\n" + styles.Meta.Render(m.status) + help
+	}
+
+	if m.mode == modeTags {
+		tagWidth := m.tagPanelWidth()
+		left := m.renderTagPanel(tagWidth, m.height-3)
+		right := strings.TrimRight(m.renderNoteRows(m.width-tagWidth-3), "\n")
+		return joinPanes(left, right) + "\n" + help
+	}
+
+	if !m.previewVisible() {
+		return m.renderNoteRows(m.width) + help
+	}
+
+	// 左にメモ一覧、右に選択中のメモのプレビュー
+	listWidth, previewWidth := m.previewWidths()
+	left := strings.TrimRight(m.renderNoteRows(listWidth), "\n")
+	right := m.renderPreview(previewWidth, m.previewHeight())
+
+	return joinPanes(left, right) + "\n" + help
+}
+
+// joinPanes puts left and right side by side with a vertical separator.
+func joinPanes(left, right string) string {
+	height := max(lipgloss.Height(left), lipgloss.Height(right))
+	sep := strings.TrimRight(strings.Repeat(" │ \n", height), "\n")
+	return lipgloss.JoinHorizontal(lipgloss.Top, left, sep, right)
+}
+
+// renderNoteRowsHeader draws the title, filter line and tag filter above the notes.
+func (m model) renderNoteRowsHeader(width int) string {
+	var b strings.Builder
+	b.WriteString(styles.Title.Render(config.Global.Theme.Symbols.NoteIcon + " Notes"))
+	b.WriteString("\n")
+	if m.filtering || m.filterActive() {
+		b.WriteString(m.renderFilterLine())
+		b.WriteString("\n")
+	}
+	if len(m.filterTags) > 0 {
+		b.WriteString(styles.Meta.Render(util.TruncateString("🏷 "+m.tagFilterLabel(), width)))
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+	return b.String()
+}
+
+// visibleNotes returns the range of notes that fit in the list and the
+// number of rows each one takes.
+func (m model) visibleNotes() (start, end, rowsPerItem int) {
+	maxItems := m.height - 6
+	if m.filtering || m.filterActive() {
+		maxItems--
+	}
+	if len(m.filterTags) > 0 {
+		maxItems--
+	}
+	// 全文検索中は一致した行を2行目に表示する
+	rowsPerItem = 1
+	if m.fullText && m.filterActive() {
+		rowsPerItem = 2
+	}
+	maxItems /= rowsPerItem
+	if maxItems < 1 {
+		maxItems = 1
+	}
+
+	if m.selectedNote >= maxItems {
+		start = m.selectedNote - maxItems + 1
+	}
+	end = start + maxItems
+	if end > len(m.notes) {
+		end = len(m.notes)
+	}
+	return start, end, rowsPerItem
+}
+
+// renderNoteRows draws the header, filter line and the visible notes within width.
+func (m model) renderNoteRows(width int) string {
 	cfg := config.Global
 	symbols := cfg.Theme.Symbols
 	formats := cfg.Formats
 
 	var b strings.Builder
-	b.WriteString(styles.Title.Render(symbols.NoteIcon + " Notes"))
-	b.WriteString("\n\n")
+	b.WriteString(m.renderNoteRowsHeader(width))
 
 	if len(m.notes) == 0 {
-		b.WriteString("メモがありません\n")
-	} else {
-		maxItems := m.height - 6
-		if maxItems < 1 {
-			maxItems = 1
-		}
-
-		start := 0
-		if m.selectedNote >= maxItems {
-			start = m.selectedNote - maxItems + 1
-		}
-		end := start + maxItems
-		if end > len(m.notes) {
-			end = len(m.notes)
+		if m.filtering || m.filterActive() || len(m.filterTags) > 0 {
+			b.WriteString(styles.Meta.Render("該当するメモがありません"))
+			b.WriteString("\n")
+		} else {
+			b.WriteString("メモがありません\n")
 		}
-
+	} else {
+		start, end, rowsPerItem := m.visibleNotes()
 		for i := start; i < end; i++ {
 			n := m.notes[i]
 			prefix := symbols.CursorEmpty
@@ -451,31 +853,49 @@ func (m model) renderNotesList() string {
 			dateWidth := runewidth.StringWidth(date)
 			prefixWidth := runewidth.StringWidth(prefix)
 			// タイトル用の幅 = 画面幅 - prefix幅 - 日付幅 - スペース2つ
-			titleMaxWidth := m.width - prefixWidth - dateWidth - 2
+			titleMaxWidth := width - prefixWidth - dateWidth - 2
 			if titleMaxWidth < 10 {
 				titleMaxWidth = 10
 			}
-			// サブディレクトリにあるノートはパスを表示
-			dir := filepath.Dir(n.ID)
-			titleDisplay := n.Title
-			if dir != "." {
-				titleDisplay = dir + "/" + n.Title
+
+			match, matched := m.noteMatches[n.ID]
+			if !matched {
+				// サブディレクトリにあるノートはパスを表示
+				title := util.TruncateString(pickerLabel(n), titleMaxWidth)
+				// パディングを計算して右揃えの日付表示
+				padding := titleMaxWidth - runewidth.StringWidth(title)
+				if padding < 0 {
+					padding = 0
+				}
+				line := fmt.Sprintf("%s%s%s %s", prefix, title, strings.Repeat(" ", padding), date)
+				b.WriteString(style.Render(line))
+				b.WriteString("\n")
+				continue
 			}
-			title := util.TruncateString(titleDisplay, titleMaxWidth)
-			// パディングを計算して右揃えの日付表示
-			titleWidth := runewidth.StringWidth(title)
+
+			title, titleWidth := highlight(match.label, match.positions, titleMaxWidth, style, styles.Match)
 			padding := titleMaxWidth - titleWidth
 			if padding < 0 {
 				padding = 0
 			}
-			line := fmt.Sprintf("%s%s%s %s", prefix, title, strings.Repeat(" ", padding), date)
-			b.WriteString(style.Render(line))
+			b.WriteString(style.Render(prefix))
+			b.WriteString(title)
+			b.WriteString(style.Render(strings.Repeat(" ", padding) + " " + date))
 			b.WriteString("\n")
+
+			if rowsPerItem == 2 {
+				indent := strings.Repeat(" ", prefixWidth+2)
+				snippetWidth := width - prefixWidth - 2
+				if match.snippet != "" {
+					line, pos := snippetWindow(match.snippet, match.snipPos, snippetWidth)
+					snippet, _ := highlight(line, pos, snippetWidth, styles.Meta, styles.Match)
+					b.WriteString(indent + snippet)
+				}
+				b.WriteString("\n")
+			}
 		}
 	}
 
-	b.WriteString(styles.Help.Render("j/k: 移動 | Enter: 詳細 | q: 終了"))
-
 	return b.String()
 }
 
@@ -486,105 +906,28 @@ func (m model) renderNoteDetail() string {
 
 	cfg := config.Global
 	symbols := cfg.Theme.Symbols
-	formats := cfg.Formats
-
-	n := m.notes[m.selectedNote]
 
 	var b strings.Builder
+	b.WriteString(m.renderDetailHeader())
 
-	// メモヘッダー
-	b.WriteString(styles.Title.Render(symbols.NoteIcon + " " + n.Title))
-	b.WriteString("\n")
-	b.WriteString(styles.Meta.Render(fmt.Sprintf("作成: %s | 更新: %s",
-		n.Created.Format(formats.DateTime),
-		n.Modified.Format(formats.DateTime))))
-	b.WriteString("\n")
-
-	if len(n.Tags) > 0 {
-		b.WriteString(styles.Meta.Render("タグ: " + strings.Join(n.Tags, ", ")))
-		b.WriteString("\n")
-	}
-
-	// メモ内容（最初の数行）
-	sepWidth := cfg.Display.SeparatorWidth
-	if sepWidth > m.width-2 {
-		sepWidth = m.width - 2
-	}
-	b.WriteString(strings.Repeat("─", sepWidth))
-	b.WriteString("\n")
-
-	maxContentLines := (m.height - 15) / 2
-	if maxContentLines < 3 {
-		maxContentLines = 3
-	}
-
-	rendered, renderErr := util.RenderMarkdown(n.Content, m.width-4, config.Global.Display.MarkdownStyle)
-	var contentLines []string
-	if renderErr != nil {
-		contentLines = strings.Split(n.Content, "\n")
-	} else {
-		contentLines = strings.Split(strings.TrimRight(rendered, "\n"), "\n")
-	}
-
-	for i, line := range contentLines {
-		if i >= maxContentLines {
-			b.WriteString(styles.Meta.Render("..."))
-			b.WriteString("\n")
-			break
-		}
-		if renderErr != nil {
-			line = util.TruncateString(line, m.width-4)
-		}
-		b.WriteString(line)
-		b.WriteString("\n")
-	}
-
-	// リンク情報
-	links := note.ExtractLinks(n.Content)
-	if len(links) > 0 {
-		b.WriteString("\n")
-		b.WriteString(styles.Meta.Render("🔗 リンク先: "))
-		found, notFound := note.ResolveLinks(m.noteStorage, links)
-		var parts []string
-		for _, ln := range found {
-			parts = append(parts, ln.Title)
-		}
-		for _, name := range notFound {
-			parts = append(parts, name+"(?)")
-		}
-		b.WriteString(styles.Meta.Render(strings.Join(parts, ", ")))
-		b.WriteString("\n")
-	}
-
-	backlinks, _ := note.FindBacklinks(m.noteStorage, n.Title)
-	if len(backlinks) > 0 {
-		b.WriteString(styles.Meta.Render("🔙 被参照: "))
-		var parts []string
-		for _, bl := range backlinks {
-			parts = append(parts, bl.Title)
-		}
-		b.WriteString(styles.Meta.Render(strings.Join(parts, ", ")))
-		b.WriteString("\n")
-	}
-
-	// 関連タスク
-	b.WriteString("\n")
-	taskTitleStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color(cfg.Theme.Colors.Selected)).MarginTop(1)
-	b.WriteString(taskTitleStyle.Render(symbols.TaskIcon + " 関連タスク"))
-	b.WriteString("\n")
-
+	k := m.keys
 	if m.addingTask {
 		priorityLabel := m.taskPriority.String()
 		if m.settingDue {
 			// 期限入力モード
 			b.WriteString(fmt.Sprintf("  [%s] %s\n", priorityLabel, m.taskInput.Value()))
 			b.WriteString(fmt.Sprintf("  期限: %s\n", m.dueInput.View()))
-			b.WriteString(styles.Meta.Render("  Enter: 確定 | Esc: 戻る"))
+			b.WriteString(styles.Meta.Render("  " + helpLine(helpItem("", k.Confirm), helpItem("戻る", k.Cancel))))
 			b.WriteString("\n")
 		} else {
 			// タスク説明入力モード
 			b.WriteString(fmt.Sprintf("  [%s] %s\n", priorityLabel, m.taskInput.View()))
-			b.WriteString(styles.Meta.Render("  Tab: 優先度変更 | Ctrl+D: 期限設定 | Enter: 確定 | Esc: キャンセル"))
+			b.WriteString(styles.Meta.Render("  " + helpLine(
+				helpItem("", k.NextPriority),
+				helpItem("", k.SetDue),
+				helpItem("", k.Confirm),
+				helpItem("", k.Cancel),
+			)))
 			b.WriteString("\n")
 		}
 	}
@@ -593,11 +936,7 @@ func (m model) renderNoteDetail() string {
 		b.WriteString(styles.Meta.Render("  タスクなし"))
 		b.WriteString("\n")
 	} else {
-		maxTaskLines := m.height - 15 - maxContentLines
-		if maxTaskLines < 3 {
-			maxTaskLines = 3
-		}
-
+		maxTaskLines := m.detailTaskRows()
 		for i, t := range m.tasks {
 			if i >= maxTaskLines {
 				b.WriteString(styles.Meta.Render(fmt.Sprintf("  ... 他 %d 件", len(m.tasks)-i)))
@@ -607,6 +946,10 @@ func (m model) renderNoteDetail() string {
 
 			prefix := symbols.CursorEmpty
 			style := styles.Normal
+			marked := m.taskSel.IsMarked(m.tasks, i, m.selectedTask)
+			if marked {
+				prefix = symbols.Marked
+			}
 			if i == m.selectedTask && !m.addingTask {
 				prefix = symbols.Cursor
 				style = styles.Selected
@@ -617,6 +960,9 @@ func (m model) renderNoteDetail() string {
 				checkbox = symbols.CheckboxDone
 				style = styles.Done
 			}
+			if marked {
+				style = styles.Marked
+			}
 
 			priority := t.Priority.String()
 			dueStr := ""
@@ -634,14 +980,125 @@ func (m model) renderNoteDetail() string {
 		}
 	}
 
-	if !m.addingTask {
-		sortLabel := "s: 期限順"
+	b.WriteString(m.renderTaskSelection())
+
+	if m.noteEditing() {
+		b.WriteString(m.renderNoteEdit())
+	} else if m.mode == modeLinks {
+		b.WriteString(styles.Help.Render(helpLine(
+			helpItem("選択", m.keys.Down, m.keys.Up),
+			helpItem("開く（(?) は作成）", m.keys.Select),
+			helpItem("戻る", m.keys.Close),
+		)))
+	} else if !m.addingTask && m.confirmTasks == nil && m.dueTasks == nil {
+		sortLabel := "期限順"
 		if m.sortByDue {
-			sortLabel = "s: 優先度順"
+			sortLabel = "優先度順"
+		}
+		b.WriteString(styles.Help.Render(helpLine(
+			helpItem("移動", k.Down, k.Up),
+			helpItem("", k.ToggleTask),
+			helpItem("", k.AddTask),
+			helpItem("", k.AttachTask),
+			helpItem("", k.DeleteTask),
+			helpItem("", k.UnlinkTask),
+			helpItem("印", k.MarkTask),
+			helpItem("", k.VisualTask),
+			helpItem("優先度", k.PriorityHigh, k.PriorityMedium, k.PriorityLow),
+			helpItem("期限", k.DueTask),
+			helpItem("移動", k.MoveTask),
+			helpItem(sortLabel, k.Sort),
+			helpItem("", k.Reader),
+			helpItem("", k.Links),
+			helpItem("履歴", k.HistoryBack, k.HistoryForward),
+			helpItem("", k.EditNote, k.RenameNote, k.DeleteNote),
+			helpItem("", k.Help),
+			helpItem("", k.Palette),
+			helpItem("", k.Back),
+		)))
+	}
+
+	return b.String()
+}
+
+// detailContentLines is the number of note lines shown in the detail view.
+func (m model) detailContentLines() int {
+	return max((m.height-15)/2, 3)
+}
+
+// detailTaskRows is the number of related tasks listed in the detail view.
+func (m model) detailTaskRows() int {
+	return max(m.height-15-m.detailContentLines(), 3)
+}
+
+// renderDetailHeader draws the note, its links and the related task title
+// above the task rows of the detail view.
+func (m model) renderDetailHeader() string {
+	cfg := config.Global
+	symbols := cfg.Theme.Symbols
+	formats := cfg.Formats
+
+	n := m.notes[m.selectedNote]
+
+	var b strings.Builder
+
+	// メモヘッダー
+	b.WriteString(styles.Title.Render(symbols.NoteIcon + " " + n.Title))
+	b.WriteString("\n")
+	b.WriteString(styles.Meta.Render(fmt.Sprintf("作成: %s | 更新: %s",
+		n.Created.Format(formats.DateTime),
+		n.Modified.Format(formats.DateTime))))
+	b.WriteString("\n")
+
+	if len(n.Tags) > 0 {
+		b.WriteString(styles.Meta.Render("タグ: " + strings.Join(n.Tags, ", ")))
+		b.WriteString("\n")
+	}
+
+	// メモ内容（最初の数行）
+	sepWidth := cfg.Display.SeparatorWidth
+	if sepWidth > m.width-2 {
+		sepWidth = m.width - 2
+	}
+	b.WriteString(strings.Repeat("─", sepWidth))
+	b.WriteString("\n")
+
+	maxContentLines := m.detailContentLines()
+
+	rendered, renderErr := util.RenderMarkdown(n.Content, m.width-4, config.Global.Display.MarkdownStyle)
+	var contentLines []string
+	if renderErr != nil {
+		contentLines = strings.Split(n.Content, "\n")
+	} else {
+		contentLines = strings.Split(strings.TrimRight(rendered, "\n"), "\n")
+	}
+
+	for i, line := range contentLines {
+		if i >= maxContentLines {
+			b.WriteString(styles.Meta.Render(fmt.Sprintf("... (%s: 全文表示)", util.HelpKeys(m.keys.Reader))))
+			b.WriteString("\n")
+			break
+		}
+		if renderErr != nil {
+			line = util.TruncateString(line, m.width-4)
 		}
-		b.WriteString(styles.Help.Render(fmt.Sprintf("j/k: 移動 | Enter/Space: 完了切替 | i: 追加 | a: 紐づけ | d: 削除 | o: 解除 | %s | Tab/Esc: 戻る", sortLabel)))
+		b.WriteString(line)
+		b.WriteString("\n")
+	}
+
+	// リンク情報
+	m.renderLinks(&b)
+	if m.status != "" {
+		b.WriteString(styles.Meta.Render(m.status))
+		b.WriteString("\n")
 	}
 
+	// 関連タスク
+	b.WriteString("\n")
+	taskTitleStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color(cfg.Theme.Colors.Selected)).MarginTop(1)
+	b.WriteString(taskTitleStyle.Render(symbols.TaskIcon + " 関連タスク"))
+	b.WriteString("\n")
+
 	return b.String()
 }
 
@@ -681,6 +1138,10 @@ func (m model) renderAttachTask() string {
 			t := m.unlinkedTasks[i]
 			prefix := symbols.CursorEmpty
 			style := styles.Normal
+			if m.attachSel.HasMark(t.ID) {
+				prefix = symbols.Marked
+				style = styles.Marked
+			}
 			if i == m.selectedUnlinked {
 				prefix = symbols.Cursor
 				style = styles.Selected
@@ -702,14 +1163,34 @@ func (m model) renderAttachTask() string {
 	}
 
 	b.WriteString("\n")
-	b.WriteString(styles.Help.Render("j/k: 移動 | Enter: 紐づけ | Esc: キャンセル"))
+	k := m.keys
+	b.WriteString(styles.Help.Render(helpLine(
+		helpItem("移動", k.Down, k.Up),
+		helpItem("印", k.MarkTask),
+		helpItem("紐づけ", k.Select),
+		helpItem("キャンセル", k.Close),
+	)))
 
 	return b.String()
 }
 
 
 func Run(noteStorage *note.Storage, taskManager *task.Manager) error {
-	p := tea.NewProgram(NewModel(noteStorage, taskManager), tea.WithAltScreen())
-	_, err := p.Run()
+	m, err := NewModel(noteStorage, taskManager)
+	if err != nil {
+		return err
+	}
+	opts := []tea.ProgramOption{tea.WithAltScreen()}
+	if config.Global.Display.Mouse {
+		opts = append(opts, tea.WithMouseCellMotion())
+	}
+	p := tea.NewProgram(m, opts...)
+
+	// 他の端末での変更を反映する (監視できなくても起動は続ける)
+	if w, err := util.Watch(p.Send, []string{noteStorage.Dir()}, []string{taskManager.FilePath()}); err == nil {
+		defer w.Close()
+	}
+
+	_, err = p.Run()
 	return err
 }
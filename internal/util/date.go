@@ -96,3 +96,70 @@ func ParseDate(input string, dateFormat string) (time.Time, error) {
 	}
 	return parsed, nil
 }
+
+// ParsePeriod parses a period such as "14d", "2w" or a Go duration ("36h").
+func ParsePeriod(s string) (time.Duration, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if s == "" {
+		return 0, nil
+	}
+
+	unit := s[len(s)-1]
+	if unit == 'd' || unit == 'w' {
+		n, err := strconv.Atoi(s[:len(s)-1])
+		if err != nil || n < 0 {
+			return 0, fmt.Errorf("無効な期間: %s (14d, 2w, 36h などが使えます)", s)
+		}
+		days := n
+		if unit == 'w' {
+			days = n * 7
+		}
+		return time.Duration(days) * 24 * time.Hour, nil
+	}
+
+	d, err := time.ParseDuration(s)
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf("無効な期間: %s (14d, 2w, 36h などが使えます)", s)
+	}
+	return d, nil
+}
+
+// RelativeDate describes t relative to now by calendar day,
+// e.g. "今日", "明日", "3日後", "2日前".
+func RelativeDate(t, now time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	day := func(x time.Time) time.Time {
+		return time.Date(x.Year(), x.Month(), x.Day(), 0, 0, 0, 0, time.Local)
+	}
+	days := int(day(t.In(time.Local)).Sub(day(now.In(time.Local))).Hours() / 24)
+	switch {
+	case days == 0:
+		return "今日"
+	case days == 1:
+		return "明日"
+	case days == -1:
+		return "昨日"
+	case days > 0:
+		return fmt.Sprintf("%d日後", days)
+	default:
+		return fmt.Sprintf("%d日前", -days)
+	}
+}
+
+// StripTimestamp removes a leading timestamp written in one of layouts
+// (e.g. "15:04 text" -> "text"). s is returned unchanged if none match.
+func StripTimestamp(s string, layouts ...string) string {
+	fields := strings.Fields(s)
+	for _, layout := range layouts {
+		n := len(strings.Fields(layout))
+		if n == 0 || len(fields) <= n {
+			continue
+		}
+		if _, err := time.Parse(layout, strings.Join(fields[:n], " ")); err == nil {
+			return strings.Join(fields[n:], " ")
+		}
+	}
+	return s
+}
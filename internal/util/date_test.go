@@ -232,3 +232,74 @@ func TestParseDate(t *testing.T) {
 		})
 	}
 }
+
+func TestParsePeriod(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    time.Duration
+		wantErr bool
+	}{
+		{"", 0, false},
+		{"14d", 14 * 24 * time.Hour, false},
+		{"2w", 14 * 24 * time.Hour, false},
+		{"0d", 0, false},
+		{"36h", 36 * time.Hour, false},
+		{" 7D ", 7 * 24 * time.Hour, false},
+		{"xd", 0, true},
+		{"-3d", 0, true},
+		{"abc", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParsePeriod(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParsePeriod(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParsePeriod(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRelativeDate(t *testing.T) {
+	now := time.Date(2026, 1, 10, 22, 0, 0, 0, time.Local)
+	tests := []struct {
+		t    time.Time
+		want string
+	}{
+		{time.Time{}, ""},
+		{time.Date(2026, 1, 10, 23, 59, 59, 0, time.Local), "今日"},
+		{time.Date(2026, 1, 11, 0, 30, 0, 0, time.Local), "明日"},
+		{time.Date(2026, 1, 9, 23, 0, 0, 0, time.Local), "昨日"},
+		{time.Date(2026, 1, 13, 9, 0, 0, 0, time.Local), "3日後"},
+		{time.Date(2025, 12, 31, 9, 0, 0, 0, time.Local), "10日前"},
+	}
+
+	for _, tt := range tests {
+		if got := RelativeDate(tt.t, now); got != tt.want {
+			t.Errorf("RelativeDate(%v) = %q, want %q", tt.t, got, tt.want)
+		}
+	}
+}
+
+func TestStripTimestamp(t *testing.T) {
+	layouts := []string{"15:04", "2006-01-02 15:04"}
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"09:30 牛乳を買う", "牛乳を買う"},
+		{"2026-01-10 09:30 牛乳を買う", "牛乳を買う"},
+		{"3個 買う", "3個 買う"},
+		{"09:30", "09:30"},
+		{"牛乳を買う", "牛乳を買う"},
+	}
+
+	for _, tt := range tests {
+		if got := StripTimestamp(tt.input, layouts...); got != tt.want {
+			t.Errorf("StripTimestamp(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
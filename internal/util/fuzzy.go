@@ -0,0 +1,40 @@
+package util
+
+import (
+	"unicode"
+)
+
+// FuzzyMatch reports whether all runes of pattern appear in s in order
+// (case-insensitive). It returns the matched rune positions in s and a score
+// where higher is better: consecutive runes and matches at word starts score more.
+func FuzzyMatch(pattern, s string) (int, []int, bool) {
+	p := []rune(pattern)
+	if len(p) == 0 {
+		return 0, nil, true
+	}
+	r := []rune(s)
+
+	positions := make([]int, 0, len(p))
+	score := 0
+	pi := 0
+	prev := -2
+	for i := 0; i < len(r) && pi < len(p); i++ {
+		if unicode.ToLower(r[i]) != unicode.ToLower(p[pi]) {
+			continue
+		}
+		score++
+		if i == prev+1 {
+			score += 2 // 連続一致
+		}
+		if i == 0 || unicode.IsSpace(r[i-1]) || unicode.IsPunct(r[i-1]) {
+			score++ // 単語の先頭
+		}
+		positions = append(positions, i)
+		prev = i
+		pi++
+	}
+	if pi < len(p) {
+		return 0, nil, false
+	}
+	return score, positions, true
+}
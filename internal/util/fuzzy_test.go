@@ -0,0 +1,41 @@
+package util
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFuzzyMatch(t *testing.T) {
+	tests := []struct {
+		pattern   string
+		s         string
+		wantOK    bool
+		positions []int
+	}{
+		{"", "anything", true, nil},
+		{"kgm", "会議 kick-off Game Meeting", true, []int{3, 12, 14}},
+		{"会議", "定例会議メモ", true, []int{2, 3}},
+		{"かいぎ", "会議", false, nil},
+		{"MTG", "週次mtg", true, []int{2, 3, 4}},
+		{"abc", "acb", false, nil},
+	}
+
+	for _, tt := range tests {
+		_, positions, ok := FuzzyMatch(tt.pattern, tt.s)
+		if ok != tt.wantOK {
+			t.Errorf("FuzzyMatch(%q, %q) ok = %v, want %v", tt.pattern, tt.s, ok, tt.wantOK)
+			continue
+		}
+		if ok && !reflect.DeepEqual(positions, tt.positions) {
+			t.Errorf("FuzzyMatch(%q, %q) positions = %v, want %v", tt.pattern, tt.s, positions, tt.positions)
+		}
+	}
+}
+
+func TestFuzzyMatchScore(t *testing.T) {
+	consecutive, _, _ := FuzzyMatch("meet", "meeting notes")
+	scattered, _, _ := FuzzyMatch("meet", "my elephant eats tea")
+	if consecutive <= scattered {
+		t.Errorf("consecutive score %d should beat scattered score %d", consecutive, scattered)
+	}
+}
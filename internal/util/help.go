@@ -0,0 +1,99 @@
+package util
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/mattn/go-runewidth"
+)
+
+// HelpSection is a titled group of bindings in the help overlay.
+type HelpSection struct {
+	Title    string
+	Bindings []key.Binding
+}
+
+// HelpView is a scrollable full-screen list of key bindings.
+type HelpView struct {
+	title    string
+	viewport viewport.Model
+}
+
+// NewHelpView lays out the enabled bindings of sections, one per line.
+func NewHelpView(title string, sections []HelpSection, styles Styles, width, height int) HelpView {
+	h := HelpView{title: title, viewport: viewport.New(width, helpViewHeight(height))}
+
+	var b strings.Builder
+	for _, s := range sections {
+		var lines [][2]string
+		keyWidth := 0
+		for _, binding := range s.Bindings {
+			if !binding.Enabled() {
+				continue
+			}
+			var labels []string
+			for _, k := range binding.Keys() {
+				labels = append(labels, KeyLabel(k))
+			}
+			keys := strings.Join(labels, " / ")
+			keyWidth = max(keyWidth, runewidth.StringWidth(keys))
+			lines = append(lines, [2]string{keys, binding.Help().Desc})
+		}
+		if len(lines) == 0 {
+			continue
+		}
+
+		b.WriteString(styles.Selected.Render(s.Title))
+		b.WriteString("\n")
+		for _, l := range lines {
+			padding := strings.Repeat(" ", keyWidth-runewidth.StringWidth(l[0]))
+			b.WriteString("  " + l[0] + padding + "  " + styles.Meta.Render(l[1]))
+			b.WriteString("\n")
+		}
+		b.WriteString("\n")
+	}
+	h.viewport.SetContent(strings.TrimRight(b.String(), "\n"))
+	return h
+}
+
+// タイトル (余白込み2行) とフッター (余白込み2行) を除いた高さ
+func helpViewHeight(height int) int {
+	return max(height-4, 1)
+}
+
+// SetSize follows a terminal resize.
+func (h *HelpView) SetSize(width, height int) {
+	h.viewport.Width = width
+	h.viewport.Height = helpViewHeight(height)
+}
+
+// Update scrolls the list by keys or the mouse wheel; it reports true when
+// the overlay should close.
+func (h HelpView) Update(msg tea.Msg) (HelpView, bool) {
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch msg.String() {
+		case "?", "esc", "q":
+			return h, true
+		case "g", "home":
+			h.viewport.GotoTop()
+			return h, false
+		case "G", "end":
+			h.viewport.GotoBottom()
+			return h, false
+		}
+	}
+	h.viewport, _ = h.viewport.Update(msg)
+	return h, false
+}
+
+func (h HelpView) View(styles Styles) string {
+	var b strings.Builder
+	b.WriteString(styles.Title.Render(h.title))
+	b.WriteString("\n")
+	b.WriteString(h.viewport.View())
+	b.WriteString("\n")
+	b.WriteString(styles.Help.Render("j/k: スクロール | ?/Esc: 閉じる"))
+	return b.String()
+}
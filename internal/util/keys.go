@@ -0,0 +1,119 @@
+package util
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+)
+
+// keyNames are the help labels of named keys.
+var keyNames = map[string]string{
+	"enter":     "Enter",
+	"esc":       "Esc",
+	"tab":       "Tab",
+	" ":         "Space",
+	"backspace": "Backspace",
+	"delete":    "Delete",
+	"home":      "Home",
+	"end":       "End",
+	"pgup":      "PgUp",
+	"pgdown":    "PgDown",
+	"up":        "↑",
+	"down":      "↓",
+	"left":      "←",
+	"right":     "→",
+}
+
+// KeyLabel formats a key for help text, e.g. "enter" → "Enter", "ctrl+d" → "Ctrl+D".
+func KeyLabel(k string) string {
+	if k == "+" {
+		return k
+	}
+	parts := strings.Split(k, "+")
+	last := parts[len(parts)-1]
+	if name, ok := keyNames[last]; ok {
+		last = name
+	}
+	if len(parts) == 1 {
+		return last
+	}
+	for i, mod := range parts[:len(parts)-1] {
+		parts[i] = strings.ToUpper(mod[:1]) + mod[1:]
+	}
+	if len([]rune(last)) == 1 {
+		last = strings.ToUpper(last)
+	}
+	parts[len(parts)-1] = last
+	return strings.Join(parts, "+")
+}
+
+// NewBinding creates a key binding shown as label in help text; an empty
+// label shows the first key.
+func NewBinding(label, desc string, keys ...string) key.Binding {
+	if label == "" {
+		label = KeyLabel(keys[0])
+	}
+	return key.NewBinding(key.WithKeys(keys...), key.WithHelp(label, desc))
+}
+
+// ApplyKeys replaces the keys of the named bindings with the configured
+// ones. An empty list disables the action, and "space" stands for " ".
+func ApplyKeys(bindings map[string]*key.Binding, overrides map[string][]string) error {
+	names := make([]string, 0, len(overrides))
+	for name := range overrides {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		b, ok := bindings[name]
+		if !ok {
+			return fmt.Errorf("不明なキー操作です: %s", name)
+		}
+		keys := make([]string, 0, len(overrides[name]))
+		for _, k := range overrides[name] {
+			if k == "space" {
+				k = " "
+			}
+			if k != "" {
+				keys = append(keys, k)
+			}
+		}
+		if len(keys) == 0 {
+			b.SetEnabled(false)
+			continue
+		}
+		b.SetKeys(keys...)
+		b.SetHelp(KeyLabel(keys[0]), b.Help().Desc)
+	}
+	return nil
+}
+
+// HelpKeys joins the help labels of the enabled bindings, e.g. "j/k".
+func HelpKeys(bindings ...key.Binding) string {
+	var labels []string
+	for _, b := range bindings {
+		if b.Enabled() {
+			labels = append(labels, b.Help().Key)
+		}
+	}
+	return strings.Join(labels, "/")
+}
+
+// YesNo returns the "(y/n)" hint of a confirmation prompt.
+func YesNo(yes, no key.Binding) string {
+	return "(" + HelpKeys(yes) + "/" + HelpKeys(no) + ")"
+}
+
+// HelpDesc joins the descriptions of the enabled bindings, e.g. "新規/編集".
+func HelpDesc(bindings ...key.Binding) string {
+	var descs []string
+	for _, b := range bindings {
+		if b.Enabled() {
+			descs = append(descs, b.Help().Desc)
+		}
+	}
+	return strings.Join(descs, "/")
+}
@@ -0,0 +1,95 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestKeyLabel(t *testing.T) {
+	tests := []struct {
+		key  string
+		want string
+	}{
+		{"j", "j"},
+		{"D", "D"},
+		{"enter", "Enter"},
+		{" ", "Space"},
+		{"up", "↑"},
+		{"ctrl+d", "Ctrl+D"},
+		{"shift+tab", "Shift+Tab"},
+		{"shift+left", "Shift+←"},
+		{"+", "+"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			if got := KeyLabel(tt.key); got != tt.want {
+				t.Errorf("KeyLabel(%q) = %q, want %q", tt.key, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestApplyKeys(t *testing.T) {
+	del := NewBinding("", "削除", "d", "x")
+	toggle := NewBinding("Enter/Space", "完了切替", "enter", " ")
+	quit := NewBinding("", "終了", "q", "ctrl+c")
+	bindings := map[string]*key.Binding{"delete": &del, "toggle": &toggle, "quit": &quit}
+
+	err := ApplyKeys(bindings, map[string][]string{
+		"delete": {},
+		"toggle": {"space", "t"},
+	})
+	if err != nil {
+		t.Fatalf("ApplyKeys() error = %v", err)
+	}
+
+	if del.Enabled() {
+		t.Error("delete should be disabled by an empty list")
+	}
+	if key.Matches(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("d")}, del) {
+		t.Error("disabled delete should not match d")
+	}
+	if !key.Matches(tea.KeyMsg{Type: tea.KeySpace, Runes: []rune(" ")}, toggle) {
+		t.Error("toggle should match space")
+	}
+	if key.Matches(tea.KeyMsg{Type: tea.KeyEnter}, toggle) {
+		t.Error("toggle should no longer match enter")
+	}
+	if got := toggle.Help().Key; got != "Space" {
+		t.Errorf("toggle help key = %q, want %q", got, "Space")
+	}
+	if got := toggle.Help().Desc; got != "完了切替" {
+		t.Errorf("toggle help desc = %q, want %q", got, "完了切替")
+	}
+	if !quit.Enabled() || quit.Help().Key != "q" {
+		t.Errorf("quit should keep its defaults, got %v", quit.Keys())
+	}
+
+	if err := ApplyKeys(bindings, map[string][]string{"unknown": {"u"}}); err == nil {
+		t.Error("ApplyKeys() should fail for an unknown action")
+	}
+}
+
+func TestHelpKeys(t *testing.T) {
+	down := NewBinding("", "下へ", "j", "down")
+	up := NewBinding("", "上へ", "k", "up")
+
+	if got := HelpKeys(down, up); got != "j/k" {
+		t.Errorf("HelpKeys() = %q, want %q", got, "j/k")
+	}
+	if got := HelpDesc(down, up); got != "下へ/上へ" {
+		t.Errorf("HelpDesc() = %q, want %q", got, "下へ/上へ")
+	}
+
+	up.SetEnabled(false)
+	if got := HelpKeys(down, up); got != "j" {
+		t.Errorf("HelpKeys() with a disabled binding = %q, want %q", got, "j")
+	}
+	down.SetEnabled(false)
+	if got := HelpKeys(down, up); got != "" {
+		t.Errorf("HelpKeys() with all disabled = %q, want empty", got)
+	}
+}
@@ -0,0 +1,198 @@
+package util
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/intiramisu/note-cli/internal/config"
+	"github.com/mattn/go-runewidth"
+)
+
+// PaletteItem is an action listed in the command palette.
+type PaletteItem struct {
+	Name     string
+	Keywords string // 名前以外で一致させる語 (英語の操作名など)
+	Key      string // 割り当てキーの表示 (なければ空)
+}
+
+type paletteMatch struct {
+	index     int
+	positions []int
+	score     int
+}
+
+// PaletteKeys are the bindings used while the palette is open, taken from
+// the key map of the TUI that embeds it.
+type PaletteKeys struct {
+	Confirm key.Binding
+	Cancel  key.Binding
+	Quit    key.Binding
+	Up      key.Binding
+	Down    key.Binding
+	Next    key.Binding
+	Prev    key.Binding
+}
+
+// Palette is a command palette that fuzzy-searches its items by name and keywords.
+type Palette struct {
+	input    textinput.Model
+	keys     PaletteKeys
+	items    []PaletteItem
+	matches  []paletteMatch
+	selected int
+	title    string
+	empty    string // 一致する項目がないときの表示
+}
+
+func NewPalette(width int, keys PaletteKeys) Palette {
+	ti := textinput.New()
+	ti.Prompt = ": "
+	ti.Width = width
+	return Palette{input: ti, keys: keys}
+}
+
+// Open lists items as commands and focuses the input.
+func (p *Palette) Open(items []PaletteItem) tea.Cmd {
+	return p.open("コマンド", "該当する操作がありません", items)
+}
+
+// OpenList reuses the palette to choose one of items, e.g. a note, under title.
+func (p *Palette) OpenList(title string, items []PaletteItem) tea.Cmd {
+	return p.open(title, "該当する項目がありません", items)
+}
+
+func (p *Palette) open(title, empty string, items []PaletteItem) tea.Cmd {
+	p.title = title
+	p.empty = empty
+	p.items = items
+	p.input.Reset()
+	p.input.Focus()
+	p.filter()
+	return textinput.Blink
+}
+
+func (p *Palette) filter() {
+	pattern := strings.TrimSpace(p.input.Value())
+	p.matches = nil
+	for i, item := range p.items {
+		if score, positions, ok := FuzzyMatch(pattern, item.Name); ok {
+			p.matches = append(p.matches, paletteMatch{index: i, positions: positions, score: score})
+		} else if score, _, ok := FuzzyMatch(pattern, item.Keywords); ok {
+			p.matches = append(p.matches, paletteMatch{index: i, score: score})
+		}
+	}
+	sort.SliceStable(p.matches, func(i, j int) bool {
+		return p.matches[i].score > p.matches[j].score
+	})
+	p.selected = 0
+}
+
+// Update handles a message while the palette is open. It returns the index
+// of the chosen item (-1 if none) and whether the palette closed.
+func (p *Palette) Update(msg tea.Msg) (int, bool, tea.Cmd) {
+	if msg, ok := msg.(tea.MouseMsg); ok {
+		switch msg.Button {
+		case tea.MouseButtonWheelDown:
+			p.selected = min(p.selected+1, max(len(p.matches)-1, 0))
+		case tea.MouseButtonWheelUp:
+			p.selected = max(p.selected-1, 0)
+		}
+		return -1, false, nil
+	}
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		k := p.keys
+		switch {
+		case key.Matches(msg, k.Cancel, k.Quit):
+			p.input.Blur()
+			return -1, true, nil
+
+		case key.Matches(msg, k.Confirm):
+			p.input.Blur()
+			if p.selected < len(p.matches) {
+				return p.matches[p.selected].index, true, nil
+			}
+			return -1, true, nil
+
+		case key.Matches(msg, k.Down, k.Next):
+			if p.selected < len(p.matches)-1 {
+				p.selected++
+			}
+			return -1, false, nil
+
+		case key.Matches(msg, k.Up, k.Prev):
+			if p.selected > 0 {
+				p.selected--
+			}
+			return -1, false, nil
+		}
+	}
+
+	var cmd tea.Cmd
+	prev := p.input.Value()
+	p.input, cmd = p.input.Update(msg)
+	if p.input.Value() != prev {
+		p.filter()
+	}
+	return -1, false, cmd
+}
+
+// View draws the input and the matching items, with their keys right-aligned.
+func (p Palette) View(styles Styles, width, height int) string {
+	symbols := config.Global.Theme.Symbols
+
+	var b strings.Builder
+	b.WriteString(styles.Title.Render(p.title))
+	b.WriteString("\n")
+	b.WriteString(p.input.View())
+	b.WriteString("\n\n")
+
+	if len(p.matches) == 0 {
+		b.WriteString(styles.Meta.Render(p.empty))
+		b.WriteString("\n")
+	}
+
+	maxItems := max(height-7, 1)
+	start := 0
+	if p.selected >= maxItems {
+		start = p.selected - maxItems + 1
+	}
+	end := min(start+maxItems, len(p.matches))
+
+	for i := start; i < end; i++ {
+		match := p.matches[i]
+		item := p.items[match.index]
+		prefix := symbols.CursorEmpty
+		style := styles.Normal
+		if i == p.selected {
+			prefix = symbols.Cursor
+			style = styles.Selected
+		}
+
+		hit := make(map[int]bool, len(match.positions))
+		for _, pos := range match.positions {
+			hit[pos] = true
+		}
+		var name strings.Builder
+		for j, r := range []rune(item.Name) {
+			if hit[j] {
+				name.WriteString(styles.Match.Render(string(r)))
+			} else {
+				name.WriteString(style.Render(string(r)))
+			}
+		}
+
+		padding := max(width-runewidth.StringWidth(prefix+item.Name+item.Key)-1, 1)
+		b.WriteString(style.Render(prefix))
+		b.WriteString(name.String())
+		b.WriteString(strings.Repeat(" ", padding))
+		b.WriteString(styles.Meta.Render(item.Key))
+		b.WriteString("\n")
+	}
+
+	k := p.keys
+	b.WriteString(styles.Help.Render(HelpKeys(k.Up, k.Down) + ": 選択 | " + HelpKeys(k.Confirm) + ": 決定 | " + HelpKeys(k.Cancel) + ": 閉じる"))
+	return b.String()
+}
@@ -0,0 +1,94 @@
+package util
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/intiramisu/note-cli/internal/config"
+	"github.com/spf13/viper"
+)
+
+func typeKeys(p *Palette, s string) {
+	for _, r := range s {
+		p.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+}
+
+func TestPalette(t *testing.T) {
+	viper.Reset()
+	config.SetDefaults()
+	config.Load()
+
+	items := []PaletteItem{
+		{Name: "新規メモ", Keywords: "new_note", Key: "n"},
+		{Name: "今日のデイリーノートを開く", Keywords: "daily today"},
+		{Name: "タスクボードを開く", Keywords: "task board"},
+	}
+
+	p := NewPalette(40, testPaletteKeys())
+	p.Open(items)
+	if len(p.matches) != len(items) {
+		t.Fatalf("empty pattern matches = %d, want %d", len(p.matches), len(items))
+	}
+
+	// 英語のキーワードでも一致する
+	typeKeys(&p, "daily")
+	if len(p.matches) != 1 || p.matches[0].index != 1 {
+		t.Fatalf("matches for %q = %+v, want only item 1", "daily", p.matches)
+	}
+	chosen, closed, _ := p.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if chosen != 1 || !closed {
+		t.Errorf("enter = %d, %v, want 1, true", chosen, closed)
+	}
+
+	// 名前の一致は強調表示用の位置を持つ
+	p.Open(items)
+	typeKeys(&p, "ボード")
+	if len(p.matches) != 1 || p.matches[0].index != 2 || len(p.matches[0].positions) != 3 {
+		t.Fatalf("matches for %q = %+v, want item 2 with positions", "ボード", p.matches)
+	}
+
+	p.Open(items)
+	p.Update(tea.KeyMsg{Type: tea.KeyDown})
+	chosen, closed, _ = p.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if chosen != 1 || !closed {
+		t.Errorf("down+enter = %d, %v, want 1, true", chosen, closed)
+	}
+
+	p.Open(items)
+	typeKeys(&p, "zzz")
+	chosen, closed, _ = p.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if chosen != -1 || !closed {
+		t.Errorf("enter without matches = %d, %v, want -1, true", chosen, closed)
+	}
+
+	p.Open(items)
+	chosen, closed, _ = p.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if chosen != -1 || !closed {
+		t.Errorf("esc = %d, %v, want -1, true", chosen, closed)
+	}
+
+	// 設定で変更したキーが使われる
+	keys := testPaletteKeys()
+	keys.Down = NewBinding("", "下へ", "ctrl+d")
+	p = NewPalette(40, keys)
+	p.Open(items)
+	p.Update(tea.KeyMsg{Type: tea.KeyDown})
+	p.Update(tea.KeyMsg{Type: tea.KeyCtrlD})
+	chosen, _, _ = p.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if chosen != 1 {
+		t.Errorf("rebound down+enter = %d, want 1", chosen)
+	}
+}
+
+func testPaletteKeys() PaletteKeys {
+	return PaletteKeys{
+		Confirm: NewBinding("", "決定", "enter"),
+		Cancel:  NewBinding("", "閉じる", "esc"),
+		Quit:    NewBinding("", "閉じる", "ctrl+c"),
+		Up:      NewBinding("", "上へ", "up"),
+		Down:    NewBinding("", "下へ", "down"),
+		Next:    NewBinding("", "次へ", "tab"),
+		Prev:    NewBinding("", "前へ", "shift+tab"),
+	}
+}
@@ -18,6 +18,8 @@ type Styles struct {
 	PriorityMedium lipgloss.Style
 	PriorityLow    lipgloss.Style
 	DoneSection    lipgloss.Style
+	Match          lipgloss.Style
+	Marked         lipgloss.Style
 }
 
 // NewStyles creates TUI styles from config.
@@ -36,5 +38,7 @@ func NewStyles(cfg *config.Config) Styles {
 		PriorityMedium: lipgloss.NewStyle().Foreground(lipgloss.Color(colors.PriorityMedium)).Bold(true),
 		PriorityLow:    lipgloss.NewStyle().Foreground(lipgloss.Color(colors.PriorityLow)).Bold(true),
 		DoneSection:    lipgloss.NewStyle().Foreground(lipgloss.Color(colors.Done)).Bold(true),
+		Match:          lipgloss.NewStyle().Foreground(lipgloss.Color(colors.Match)).Bold(true).Underline(true),
+		Marked:         lipgloss.NewStyle().Foreground(lipgloss.Color(colors.Match)).Bold(true),
 	}
 }
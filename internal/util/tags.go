@@ -0,0 +1,43 @@
+package util
+
+import "strings"
+
+// TagSeparator separates the levels of a hierarchical tag ("work/projectA").
+const TagSeparator = "/"
+
+// TagMatches reports whether tag is query or nested under it:
+// "work/projectA" matches "work", but "workshop" does not.
+func TagMatches(tag, query string) bool {
+	return tag == query || strings.HasPrefix(tag, query+TagSeparator)
+}
+
+// TagAncestors returns the parents of tag, outermost first ("a", "a/b" for "a/b/c").
+func TagAncestors(tag string) []string {
+	parts := strings.Split(tag, TagSeparator)
+	var ancestors []string
+	for i := 1; i < len(parts); i++ {
+		ancestors = append(ancestors, strings.Join(parts[:i], TagSeparator))
+	}
+	return ancestors
+}
+
+// RenameTag replaces old, and tags nested under it, with newTag ("work/a" becomes
+// "job/a" when renaming work to job). Duplicates created by the rename are dropped.
+// It reports whether anything changed.
+func RenameTag(tags []string, old, newTag string) ([]string, bool) {
+	changed := false
+	seen := make(map[string]bool)
+	out := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		if TagMatches(tag, old) {
+			tag = newTag + strings.TrimPrefix(tag, old)
+			changed = true
+		}
+		if seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		out = append(out, tag)
+	}
+	return out, changed
+}
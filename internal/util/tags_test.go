@@ -0,0 +1,56 @@
+package util
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTagMatches(t *testing.T) {
+	tests := []struct {
+		tag, query string
+		want       bool
+	}{
+		{"work", "work", true},
+		{"work/projectA", "work", true},
+		{"work/projectA/design", "work/projectA", true},
+		{"workshop", "work", false},
+		{"work", "work/projectA", false},
+		{"Work", "work", false},
+	}
+	for _, tt := range tests {
+		if got := TagMatches(tt.tag, tt.query); got != tt.want {
+			t.Errorf("TagMatches(%q, %q) = %v, want %v", tt.tag, tt.query, got, tt.want)
+		}
+	}
+}
+
+func TestTagAncestors(t *testing.T) {
+	if got := TagAncestors("a/b/c"); !reflect.DeepEqual(got, []string{"a", "a/b"}) {
+		t.Errorf("TagAncestors(a/b/c) = %v", got)
+	}
+	if got := TagAncestors("a"); len(got) != 0 {
+		t.Errorf("TagAncestors(a) = %v, want none", got)
+	}
+}
+
+func TestRenameTag(t *testing.T) {
+	tests := []struct {
+		name        string
+		tags        []string
+		old, newTag string
+		want        []string
+		changed     bool
+	}{
+		{"exact", []string{"Work", "go"}, "Work", "work", []string{"work", "go"}, true},
+		{"children", []string{"work/projectA", "workshop"}, "work", "仕事", []string{"仕事/projectA", "workshop"}, true},
+		{"dedupe", []string{"work", "仕事"}, "仕事", "work", []string{"work"}, true},
+		{"nest under new parent", []string{"projectA"}, "projectA", "work/projectA", []string{"work/projectA"}, true},
+		{"unchanged", []string{"go"}, "work", "job", []string{"go"}, false},
+	}
+	for _, tt := range tests {
+		got, changed := RenameTag(tt.tags, tt.old, tt.newTag)
+		if !reflect.DeepEqual(got, tt.want) || changed != tt.changed {
+			t.Errorf("%s: RenameTag() = %v, %v, want %v, %v", tt.name, got, changed, tt.want, tt.changed)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package util
+
+import (
+	"strings"
+	"text/template"
+	"time"
+)
+
+// templateEscaper expands the escapes users type in shell-quoted --format strings.
+var templateEscaper = strings.NewReplacer(`\t`, "\t", `\n`, "\n", `\\`, `\`)
+
+// expandTemplateEscapes applies templateEscaper to the text outside {{ }}
+// actions; string literals inside actions keep Go's own escapes.
+func expandTemplateEscapes(text string) string {
+	var b strings.Builder
+	for {
+		start := strings.Index(text, "{{")
+		if start < 0 {
+			b.WriteString(templateEscaper.Replace(text))
+			return b.String()
+		}
+		b.WriteString(templateEscaper.Replace(text[:start]))
+		end := actionEnd(text, start+2)
+		b.WriteString(text[start:end])
+		text = text[end:]
+	}
+}
+
+// actionEnd returns the index just past the "}}" closing the action that
+// starts before i, skipping "}}" inside quoted strings.
+func actionEnd(text string, i int) int {
+	var quote byte
+	for ; i < len(text); i++ {
+		c := text[i]
+		switch {
+		case quote != 0:
+			if c == '\\' && quote == '"' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '`' || c == '\'':
+			quote = c
+		case strings.HasPrefix(text[i:], "}}"):
+			return i + 2
+		}
+	}
+	return len(text)
+}
+
+// TemplateFuncs returns the helper functions available in list --format templates.
+func TemplateFuncs(dateFormat, dateTimeFormat string) template.FuncMap {
+	formatTime := func(layout string) func(time.Time) string {
+		return func(t time.Time) string {
+			if t.IsZero() {
+				return ""
+			}
+			return t.Format(layout)
+		}
+	}
+	return template.FuncMap{
+		"join":     strings.Join,
+		"date":     formatTime(dateFormat),
+		"datetime": formatTime(dateTimeFormat),
+		"truncate": func(width int, s string) string { return TruncateString(s, width) },
+		"relative": func(t time.Time) string { return RelativeDate(t, time.Now()) },
+		"upper":    strings.ToUpper,
+		"lower":    strings.ToLower,
+	}
+}
+
+// ParseListTemplate parses a one-line-per-item output template.
+// "\t" and "\n" written literally outside {{ }} are expanded, and a trailing
+// newline is added.
+func ParseListTemplate(text string, funcs template.FuncMap) (*template.Template, error) {
+	text = expandTemplateEscapes(text)
+	if !strings.HasSuffix(text, "\n") {
+		text += "\n"
+	}
+	return template.New("format").Funcs(funcs).Option("missingkey=error").Parse(text)
+}
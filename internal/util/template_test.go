@@ -0,0 +1,57 @@
+package util
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseListTemplate(t *testing.T) {
+	data := struct {
+		Title   string
+		Tags    []string
+		Created time.Time
+		Due     time.Time
+	}{
+		Title:   "とても長いタイトルのメモ",
+		Tags:    []string{"a", "b"},
+		Created: time.Date(2026, 1, 10, 9, 5, 0, 0, time.Local),
+	}
+
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{"escapes and join", `{{.Title}}\t{{join .Tags ","}}`, "とても長いタイトルのメモ\ta,b\n"},
+		{"date", `{{date .Created}} {{datetime .Created}}`, "2026/01/10 01/10 09:05\n"},
+		{"zero date", `[{{date .Due}}][{{relative .Due}}]`, "[][]\n"},
+		{"truncate pipeline", `{{.Title | truncate 10}}`, "とても...\n"},
+		{"keeps newline", "{{upper \"x\"}}\n", "X\n"},
+		{"escape in string literal", `{{join .Tags "\n"}}\t{{"}}"}}`, "a\nb\t}}\n"},
+		{"escaped backslash", `a\\n{{join .Tags "\\"}}`, "a\\na\\b\n"},
+	}
+
+	funcs := TemplateFuncs("2006/01/02", "01/02 15:04")
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpl, err := ParseListTemplate(tt.text, funcs)
+			if err != nil {
+				t.Fatalf("ParseListTemplate() error = %v", err)
+			}
+			var b strings.Builder
+			if err := tmpl.Execute(&b, data); err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+			if b.String() != tt.want {
+				t.Errorf("got %q, want %q", b.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestParseListTemplateError(t *testing.T) {
+	if _, err := ParseListTemplate("{{.Title", TemplateFuncs("", "")); err == nil {
+		t.Error("ParseListTemplate() should fail on a broken template")
+	}
+}
@@ -0,0 +1,139 @@
+package util
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/fsnotify/fsnotify"
+)
+
+// エディタの保存などで続けて届くイベントをまとめる待ち時間
+const watchDebounce = 200 * time.Millisecond
+
+// FilesChangedMsg is sent to a TUI when watched notes or the tasks file
+// change on disk.
+type FilesChangedMsg struct{}
+
+// Watcher reports changes to the notes under a directory tree and to
+// individual files.
+type Watcher struct {
+	watcher *fsnotify.Watcher
+	dirs    map[string]bool
+	files   map[string]bool
+
+	mu    sync.Mutex
+	timer *time.Timer
+}
+
+// Watch watches the .md files under dirs (hidden directories such as
+// .templates are skipped) and the given files, and calls send with
+// FilesChangedMsg once the changes settle.
+func Watch(send func(tea.Msg), dirs []string, files []string) (*Watcher, error) {
+	fw, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+	w := &Watcher{watcher: fw, dirs: map[string]bool{}, files: map[string]bool{}}
+
+	for _, dir := range dirs {
+		if err := w.addTree(dir); err != nil {
+			fw.Close()
+			return nil, err
+		}
+	}
+	// ファイルは置き換え保存でも追えるよう、親ディレクトリを監視する
+	for _, file := range files {
+		file = filepath.Clean(file)
+		w.files[file] = true
+		if dir := filepath.Dir(file); !w.dirs[dir] {
+			if err := fw.Add(dir); err != nil {
+				fw.Close()
+				return nil, err
+			}
+		}
+	}
+
+	go w.run(send)
+	return w, nil
+}
+
+// addTree watches dir and its subdirectories.
+func (w *Watcher) addTree(dir string) error {
+	return filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+		if err != nil || !d.IsDir() {
+			return nil
+		}
+		if path != dir && strings.HasPrefix(d.Name(), ".") {
+			return filepath.SkipDir
+		}
+		if err := w.watcher.Add(path); err != nil {
+			return err
+		}
+		w.dirs[filepath.Clean(path)] = true
+		return nil
+	})
+}
+
+func (w *Watcher) run(send func(tea.Msg)) {
+	for {
+		select {
+		case ev, ok := <-w.watcher.Events:
+			if !ok {
+				return
+			}
+			if w.relevant(ev) {
+				w.notify(send)
+			}
+		case _, ok := <-w.watcher.Errors:
+			if !ok {
+				return
+			}
+		}
+	}
+}
+
+// relevant reports whether ev changes a note, a watched file or the directory
+// tree. New directories are watched as they appear.
+func (w *Watcher) relevant(ev fsnotify.Event) bool {
+	if ev.Op == fsnotify.Chmod {
+		return false
+	}
+	name := filepath.Clean(ev.Name)
+	if w.files[name] {
+		return true
+	}
+	if !w.dirs[filepath.Dir(name)] || strings.HasPrefix(filepath.Base(name), ".") {
+		return false
+	}
+	if ev.Has(fsnotify.Create) {
+		if info, err := os.Stat(name); err == nil && info.IsDir() {
+			w.addTree(name)
+			return true
+		}
+	}
+	return strings.HasSuffix(name, ".md") || w.dirs[name]
+}
+
+// notify sends FilesChangedMsg after watchDebounce without further events.
+func (w *Watcher) notify(send func(tea.Msg)) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.timer != nil {
+		w.timer.Stop()
+	}
+	w.timer = time.AfterFunc(watchDebounce, func() { send(FilesChangedMsg{}) })
+}
+
+// Close stops watching.
+func (w *Watcher) Close() error {
+	w.mu.Lock()
+	if w.timer != nil {
+		w.timer.Stop()
+	}
+	w.mu.Unlock()
+	return w.watcher.Close()
+}
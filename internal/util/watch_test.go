@@ -0,0 +1,72 @@
+package util
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// waitChanged reports whether FilesChangedMsg arrives within timeout.
+func waitChanged(ch <-chan tea.Msg, timeout time.Duration) bool {
+	select {
+	case msg := <-ch:
+		_, ok := msg.(FilesChangedMsg)
+		return ok
+	case <-time.After(timeout):
+		return false
+	}
+}
+
+func TestWatch(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "note-cli-test-*")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	tasksFile := filepath.Join(tmpDir, ".tasks.yaml")
+	ch := make(chan tea.Msg, 10)
+	w, err := Watch(func(msg tea.Msg) { ch <- msg }, []string{tmpDir}, []string{tasksFile})
+	if err != nil {
+		t.Fatalf("Watch() error = %v", err)
+	}
+	defer w.Close()
+
+	write := func(path string) {
+		t.Helper()
+		if err := os.WriteFile(path, []byte("x"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	write(filepath.Join(tmpDir, "memo.md"))
+	if !waitChanged(ch, 2*time.Second) {
+		t.Error("no FilesChangedMsg after writing a note")
+	}
+
+	write(tasksFile)
+	if !waitChanged(ch, 2*time.Second) {
+		t.Error("no FilesChangedMsg after writing the tasks file")
+	}
+
+	// 後から作ったサブディレクトリも監視する
+	sub := filepath.Join(tmpDir, "sub")
+	if err := os.Mkdir(sub, 0755); err != nil {
+		t.Fatal(err)
+	}
+	waitChanged(ch, 2*time.Second)
+	write(filepath.Join(sub, "nested.md"))
+	if !waitChanged(ch, 2*time.Second) {
+		t.Error("no FilesChangedMsg after writing a note in a new directory")
+	}
+
+	// メモ以外のファイルや隠しファイルは無視する
+	write(filepath.Join(tmpDir, "memo.md.swp"))
+	write(filepath.Join(tmpDir, ".hidden.md"))
+	if waitChanged(ch, 500*time.Millisecond) {
+		t.Error("FilesChangedMsg after writing files that are not notes")
+	}
+}